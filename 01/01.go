@@ -1,75 +1,148 @@
-package main
+package day01
 
 import (
-	"log"
-	"strconv"
+	"embed"
+	"fmt"
+	"os"
 
 	"github.com/angristan/advent-of-code-2023/utils"
 )
 
-func main() {
-	input := utils.ParseInput("input.txt")
+//go:embed input.txt example*.txt
+var inputs embed.FS
 
-	sum := ComputeCalibrationSum(input)
+// Run prints the calibration sums of both parts, or runs the diagnose
+// subcommand listing the calibration of every line.
+func Run(args []string) error {
+	config := utils.NoConfig{}
 
-	log.Printf("Sum: %d", sum)
-}
+	return utils.DayRunner{
+		Day:    "01",
+		Inputs: inputs,
+		Config: &config,
+		Lint:   LintInput,
+		Solve: func(input []string) error {
+			part1Sum, err := ComputeCalibrationSumChecked(input, runPart1Options)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Part 1: %d\n", part1Sum)
+
+			part2Sum, err := ComputeCalibrationSumChecked(input, Part2Options)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Part 2: %d\n", part2Sum)
+
+			return nil
+		},
+		Stream: func(options *utils.Options) error {
+			// Each part streams the input on its own
+			for part, calibration := range []CalibrationOptions{runPart1Options, Part2Options} {
+				err := utils.StreamInput(inputs, options, func(lines utils.LineIterator) error {
+					sum, err := ComputeCalibrationSumStream(lines, calibration)
+					if err != nil {
+						return err
+					}
+
+					fmt.Printf("Part %d: %d\n", part+1, sum)
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Subcommands: map[string]utils.Subcommand{
+			"diagnose": {
+				Usage: "diagnose",
+				Run: func(input []string, options *utils.Options) error {
+					calibration := Part2Options
+					calibration.MissingDigits = MissingDigitsZero
+
+					calibrations, err := DiagnoseCalibration(input, calibration)
+					if err != nil {
+						return err
+					}
 
-var digitsSpelledOut = map[string]int{
-	"zero":  0,
-	"one":   1,
-	"two":   2,
-	"three": 3,
-	"four":  4,
-	"five":  5,
-	"six":   6,
-	"seven": 7,
-	"eight": 8,
-	"nine":  9,
+					PrintCalibrations(os.Stdout, calibrations)
+					return nil
+				},
+			},
+		},
+	}.Run(args)
 }
 
 type CalibrationInput []string
 
-func ComputeCalibrationSum(input CalibrationInput) int {
+type CalibrationOptions struct {
+	// Words recognised as digits on each line
+	Vocabulary *Vocabulary
+	// What to do with lines without any digit
+	MissingDigits MissingDigitsPolicy
+}
+
+var (
+	Part1Options = CalibrationOptions{Vocabulary: NewVocabulary(Figures)}
+	Part2Options = CalibrationOptions{Vocabulary: NewVocabulary(MergeWords(Figures, English))}
+
+	// Lines only spelling out their digits, like in the second example,
+	// have nothing to calibrate in part 1. Lint reports them.
+	runPart1Options = CalibrationOptions{Vocabulary: Part1Options.Vocabulary, MissingDigits: MissingDigitsSkip}
+)
+
+func ComputeCalibrationSum(input CalibrationInput, options CalibrationOptions) int {
 	sum := 0
 
 	for _, line := range input {
-		digitsOnTheLine := []rune{}
-		for i, char := range line {
-			if utils.IsRuneADigit(char) {
-				if len(digitsOnTheLine) < 2 {
-					digitsOnTheLine = append(digitsOnTheLine, char)
-				} else {
-					digitsOnTheLine[1] = char
-				}
-			} else {
-				for spelledOutDigit, intValue := range digitsSpelledOut {
-					if i+len(spelledOutDigit) > len(line) {
-						continue
-					}
-					possibleDigit := line[i : i+len(spelledOutDigit)]
-					if possibleDigit == spelledOutDigit {
-						if len(digitsOnTheLine) < 2 {
-							digitsOnTheLine = append(digitsOnTheLine, rune(intValue+'0'))
-						} else {
-							digitsOnTheLine[1] = rune(intValue + '0')
-						}
-					}
-				}
-			}
-		}
+		sum += ComputeLineCalibration(line, options)
+	}
+
+	return sum
+}
+
+// ComputeCalibrationSumChecked is ComputeCalibrationSum, returning an error
+// instead of panicking on lines without digits.
+func ComputeCalibrationSumChecked(input CalibrationInput, options CalibrationOptions) (int, error) {
+	sum := 0
 
-		if len(digitsOnTheLine) == 1 {
-			digitsOnTheLine = append(digitsOnTheLine, digitsOnTheLine[0])
+	for i, line := range input {
+		calibration, err := CalibrateLine(line, i+1, options)
+		if err != nil {
+			return 0, fmt.Errorf("line %d: %w", i+1, err)
 		}
 
-		number, err := strconv.Atoi(string(digitsOnTheLine))
+		sum += calibration.Value
+	}
+
+	return sum, nil
+}
+
+// ComputeCalibrationSumStream computes the same sum as ComputeCalibrationSum
+// while only holding the current line in memory.
+func ComputeCalibrationSumStream(lines utils.LineIterator, options CalibrationOptions) (int, error) {
+	sum := 0
+
+	for lineNumber := 1; lines.Scan(); lineNumber++ {
+		calibration, err := CalibrateLine(lines.Text(), lineNumber, options)
 		if err != nil {
-			panic(err)
+			return 0, fmt.Errorf("line %d: %w", lineNumber, err)
 		}
 
-		sum += number
+		sum += calibration.Value
 	}
 
-	return sum
+	return sum, lines.Err()
+}
+
+// ComputeLineCalibration panics on lines without digits
+// when options.MissingDigits is MissingDigitsError.
+func ComputeLineCalibration(line string, options CalibrationOptions) int {
+	calibration, err := CalibrateLine(line, 0, options)
+	if err != nil {
+		panic(err)
+	}
+
+	return calibration.Value
 }
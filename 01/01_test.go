@@ -1,13 +1,16 @@
-package main
+package day01
 
 import (
 	"testing"
+
+	"github.com/angristan/advent-of-code-2023/utils"
 )
 
 func TestComputeCalibrationSum(t *testing.T) {
 	type test struct {
-		input []string
-		want  int
+		input   []string
+		options CalibrationOptions
+		want    int
 	}
 
 	tests := []test{
@@ -18,6 +21,17 @@ func TestComputeCalibrationSum(t *testing.T) {
 				"a1b2c3d4e5f",
 				"treb7uchet",
 			},
+			Part1Options,
+			142,
+		},
+		{
+			[]string{
+				"1abc2",
+				"pqr3stu8vwx",
+				"a1b2c3d4e5f",
+				"treb7uchet",
+			},
+			Part2Options,
 			142,
 		},
 		{
@@ -30,12 +44,26 @@ func TestComputeCalibrationSum(t *testing.T) {
 				"zoneight234",
 				"7pqrstsixteen",
 			},
+			Part2Options,
 			281,
 		},
+		{
+			// Spelled out digits are ignored in part 1
+			[]string{
+				"two1nine",
+				"abcone2threexyz",
+				"xtwone3four",
+				"4nineeightseven2",
+				"zoneight234",
+				"7pqrstsixteen",
+			},
+			Part1Options,
+			11 + 22 + 33 + 42 + 24 + 77,
+		},
 	}
 
 	for _, tc := range tests {
-		got := ComputeCalibrationSum(tc.input)
+		got := ComputeCalibrationSum(tc.input, tc.options)
 
 		if got != tc.want {
 			t.Errorf("Expected sum to be %d, got %d", tc.want, got)
@@ -44,7 +72,7 @@ func TestComputeCalibrationSum(t *testing.T) {
 	}
 }
 
-func BenchmarkComputeCalibrationSum(b *testing.B) {
+func TestComputeCalibrationSumStream(t *testing.T) {
 	input := []string{
 		"two1nine",
 		"eightwothree",
@@ -55,7 +83,26 @@ func BenchmarkComputeCalibrationSum(b *testing.B) {
 		"7pqrstsixteen",
 	}
 
-	for i := 0; i < b.N; i++ {
-		ComputeCalibrationSum(input)
+	liveHeapAtEnd := uint64(0)
+	liveHeapBefore := utils.LiveHeap()
+
+	lines := &utils.GeneratedLines{
+		Count: len(input) * 100000,
+		Line:  func(i int) string { return input[i%len(input)] },
+		AtEnd: func() { liveHeapAtEnd = utils.LiveHeap() },
+	}
+
+	got, err := ComputeCalibrationSumStream(lines, Part2Options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := 281 * 100000; got != want {
+		t.Errorf("Expected sum to be %d, got %d", want, got)
+	}
+
+	// Nothing should be retained from one line to the next
+	if growth := int64(liveHeapAtEnd) - int64(liveHeapBefore); growth > 64*1024 {
+		t.Errorf("Expected live heap to stay flat, it grew by %d bytes", growth)
 	}
 }
@@ -0,0 +1,87 @@
+package day01
+
+import (
+	"fmt"
+	"io"
+)
+
+type MissingDigitsPolicy int
+
+const (
+	// Lines without digits are an error
+	MissingDigitsError MissingDigitsPolicy = iota
+	// Lines without digits are left out of the results and the sum
+	MissingDigitsSkip
+	// Lines without digits are worth 0
+	MissingDigitsZero
+)
+
+// LineCalibration explains how the value of a line was computed.
+// First and Last are zero tokens when the line has no digit.
+type LineCalibration struct {
+	LineNumber int
+	Line       string
+	First      Token
+	Last       Token
+	Value      int
+	NoDigits   bool
+}
+
+// CalibrateLine computes the calibration value of a single line, lineNumber
+// being only used for reporting.
+func CalibrateLine(line string, lineNumber int, options CalibrationOptions) (LineCalibration, error) {
+	calibration := LineCalibration{LineNumber: lineNumber, Line: line}
+
+	first, ok := options.Vocabulary.FirstToken(line)
+	if !ok {
+		if options.MissingDigits == MissingDigitsError {
+			return calibration, fmt.Errorf("no digit found in %q", line)
+		}
+
+		calibration.NoDigits = true
+		return calibration, nil
+	}
+	last, _ := options.Vocabulary.LastToken(line)
+
+	calibration.First = first
+	calibration.Last = last
+	calibration.Value = first.Value*10 + last.Value
+
+	return calibration, nil
+}
+
+// DiagnoseCalibration returns the details of every line, to audit
+// where a surprising total comes from.
+func DiagnoseCalibration(input CalibrationInput, options CalibrationOptions) ([]LineCalibration, error) {
+	calibrations := []LineCalibration{}
+
+	for i, line := range input {
+		calibration, err := CalibrateLine(line, i+1, options)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		if calibration.NoDigits && options.MissingDigits == MissingDigitsSkip {
+			continue
+		}
+
+		calibrations = append(calibrations, calibration)
+	}
+
+	return calibrations, nil
+}
+
+func PrintCalibrations(out io.Writer, calibrations []LineCalibration) {
+	for _, calibration := range calibrations {
+		if calibration.NoDigits {
+			fmt.Fprintf(out, "line %d: no digit, value 0\n", calibration.LineNumber)
+			continue
+		}
+
+		fmt.Fprintf(out, "line %d: first %q (%s at %d), last %q (%s at %d), value %d\n",
+			calibration.LineNumber,
+			calibration.First.Text, calibration.First.Kind, calibration.First.Offset,
+			calibration.Last.Text, calibration.Last.Kind, calibration.Last.Offset,
+			calibration.Value)
+	}
+}
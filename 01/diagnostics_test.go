@@ -0,0 +1,101 @@
+package day01
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiagnoseCalibration(t *testing.T) {
+	input := CalibrationInput{
+		"xtwone3four",
+		"nothing here",
+		"7pqrstsixteen",
+	}
+
+	options := Part2Options
+	options.MissingDigits = MissingDigitsZero
+
+	want := []LineCalibration{
+		{
+			LineNumber: 1,
+			Line:       "xtwone3four",
+			First:      Token{Value: 2, Text: "two", Offset: 1, Kind: WordToken},
+			Last:       Token{Value: 4, Text: "four", Offset: 7, Kind: WordToken},
+			Value:      24,
+		},
+		{
+			LineNumber: 2,
+			Line:       "nothing here",
+			NoDigits:   true,
+		},
+		{
+			LineNumber: 3,
+			Line:       "7pqrstsixteen",
+			First:      Token{Value: 7, Text: "7", Offset: 0, Kind: FigureToken},
+			Last:       Token{Value: 6, Text: "six", Offset: 6, Kind: WordToken},
+			Value:      76,
+		},
+	}
+
+	got, err := DiagnoseCalibration(input, options)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	options.MissingDigits = MissingDigitsSkip
+	got, err = DiagnoseCalibration(input, options)
+	assert.NoError(t, err)
+	assert.Equal(t, []LineCalibration{want[0], want[2]}, got)
+
+	options.MissingDigits = MissingDigitsError
+	_, err = DiagnoseCalibration(input, options)
+	assert.EqualError(t, err, `line 2: no digit found in "nothing here"`)
+}
+
+func TestDiagnoseCalibrationUnicodeOffsets(t *testing.T) {
+	options := CalibrationOptions{Vocabulary: NewVocabulary(MergeWords(ArabicIndicFigures, French))}
+
+	got, err := DiagnoseCalibration(CalibrationInput{"é٣zéro"}, options)
+	assert.NoError(t, err)
+	assert.Equal(t, Token{Value: 3, Text: "٣", Offset: 2, Kind: FigureToken}, got[0].First)
+	assert.Equal(t, Token{Value: 0, Text: "zéro", Offset: 4, Kind: WordToken}, got[0].Last)
+	assert.Equal(t, 30, got[0].Value)
+}
+
+func TestComputeCalibrationSumMissingDigits(t *testing.T) {
+	input := CalibrationInput{"1abc2", "abc", "treb7uchet"}
+
+	options := Part1Options
+	options.MissingDigits = MissingDigitsZero
+	assert.Equal(t, 12+77, ComputeCalibrationSum(input, options))
+
+	options.MissingDigits = MissingDigitsSkip
+	assert.Equal(t, 12+77, ComputeCalibrationSum(input, options))
+
+	assert.Panics(t, func() { ComputeCalibrationSum(input, Part1Options) })
+
+	_, err := ComputeCalibrationSumChecked(input, Part1Options)
+	assert.EqualError(t, err, `line 2: no digit found in "abc"`)
+
+	sum, err := ComputeCalibrationSumChecked(input, options)
+	assert.NoError(t, err)
+	assert.Equal(t, 12+77, sum)
+}
+
+func TestPrintCalibrations(t *testing.T) {
+	calibrations := []LineCalibration{
+		{
+			LineNumber: 1,
+			First:      Token{Value: 2, Text: "two", Offset: 1, Kind: WordToken},
+			Last:       Token{Value: 3, Text: "3", Offset: 6, Kind: FigureToken},
+			Value:      23,
+		},
+		{LineNumber: 2, NoDigits: true},
+	}
+
+	out := strings.Builder{}
+	PrintCalibrations(&out, calibrations)
+
+	assert.Equal(t, "line 1: first \"two\" (word at 1), last \"3\" (figure at 6), value 23\nline 2: no digit, value 0\n", out.String())
+}
@@ -0,0 +1,26 @@
+package day01
+
+import (
+	"github.com/angristan/advent-of-code-2023/utils"
+)
+
+func LintInput(input []string) []utils.LintProblem {
+	problems := []utils.LintProblem{}
+
+	if len(input) == 0 {
+		return append(problems, utils.Lintf(0, "input is empty"))
+	}
+
+	for i, line := range input {
+		if _, ok := Part2Options.Vocabulary.First(line); !ok {
+			problems = append(problems, utils.Lintf(i+1, "no digit found in %q", line))
+			continue
+		}
+
+		if _, ok := Part1Options.Vocabulary.First(line); !ok {
+			problems = append(problems, utils.Lintf(i+1, "no figure found in %q, part 1 skips it", line))
+		}
+	}
+
+	return problems
+}
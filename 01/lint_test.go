@@ -0,0 +1,37 @@
+package day01
+
+import (
+	"testing"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintInput(t *testing.T) {
+	type test struct {
+		input    []string
+		expected []utils.LintProblem
+	}
+
+	tests := []test{
+		{
+			input:    []string{"1abc2", "pqr3stu8vwx", "two1nine"},
+			expected: []utils.LintProblem{},
+		},
+		{
+			input: []string{"1abc2", "abcdef", "xtwone"},
+			expected: []utils.LintProblem{
+				{Line: 2, Message: "no digit found in \"abcdef\""},
+				{Line: 3, Message: "no figure found in \"xtwone\", part 1 skips it"},
+			},
+		},
+		{
+			input:    []string{},
+			expected: []utils.LintProblem{{Line: 0, Message: "input is empty"}},
+		},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, LintInput(tc.input))
+	}
+}
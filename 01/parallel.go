@@ -0,0 +1,59 @@
+package day01
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// ComputeCalibrationSumParallel splits the input into contiguous shards
+// summed by their own goroutine, giving the same total as
+// ComputeCalibrationSum. shards <= 0 uses one shard per CPU.
+func ComputeCalibrationSumParallel(input CalibrationInput, options CalibrationOptions, shards int) (int, error) {
+	if shards <= 0 {
+		shards = runtime.GOMAXPROCS(0)
+	}
+	if shards > len(input) {
+		shards = len(input)
+	}
+	if shards == 0 {
+		return 0, nil
+	}
+
+	partialSums := make([]int, shards)
+	errs := make([]error, shards)
+
+	wg := sync.WaitGroup{}
+	for shard := 0; shard < shards; shard++ {
+		start := shard * len(input) / shards
+		end := (shard + 1) * len(input) / shards
+
+		wg.Add(1)
+		go func(shard, start, end int) {
+			defer wg.Done()
+
+			for i := start; i < end; i++ {
+				calibration, err := CalibrateLine(input[i], i+1, options)
+				if err != nil {
+					errs[shard] = fmt.Errorf("line %d: %w", i+1, err)
+					return
+				}
+
+				partialSums[shard] += calibration.Value
+			}
+		}(shard, start, end)
+	}
+	wg.Wait()
+
+	sum := 0
+	for shard := range partialSums {
+		// Shards are in input order, so this is the error of the first bad line
+		if errs[shard] != nil {
+			return 0, errs[shard]
+		}
+
+		sum += partialSums[shard]
+	}
+
+	return sum, nil
+}
@@ -0,0 +1,48 @@
+package day01
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// make race runs this with -race, to check that shards don't share any state
+func TestComputeCalibrationSumParallel(t *testing.T) {
+	input := generateCalibrationInput(5000, 60)
+	want := ComputeCalibrationSum(input, Part2Options)
+
+	for _, shards := range []int{0, 1, 2, 3, 7, 64, 10000} {
+		got, err := ComputeCalibrationSumParallel(input, Part2Options, shards)
+
+		assert.NoError(t, err)
+		assert.Equal(t, want, got, "%d shards", shards)
+	}
+
+	got, err := ComputeCalibrationSumParallel(CalibrationInput{}, Part2Options, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, got)
+}
+
+func TestComputeCalibrationSumParallelMissingDigits(t *testing.T) {
+	input := CalibrationInput{"1abc2", "pqr3stu8vwx", "abc", "a1b2c3d4e5f", "xyz", "treb7uchet"}
+
+	_, err := ComputeCalibrationSumParallel(input, Part1Options, 3)
+	assert.EqualError(t, err, `line 3: no digit found in "abc"`)
+
+	options := Part1Options
+	options.MissingDigits = MissingDigitsZero
+
+	got, err := ComputeCalibrationSumParallel(input, options, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 142, got)
+}
+
+// Compare with BenchmarkLargeInputMatcher, which runs on the same input
+func BenchmarkComputeCalibrationSumParallel(b *testing.B) {
+	input := generateCalibrationInput(1000, 200)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		ComputeCalibrationSumParallel(input, Part2Options, 0)
+	}
+}
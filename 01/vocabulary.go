@@ -0,0 +1,277 @@
+package day01
+
+import (
+	"maps"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Words maps every way of writing a digit to its value.
+type Words map[string]int
+
+var (
+	Figures = Words{
+		"0": 0, "1": 1, "2": 2, "3": 3, "4": 4, "5": 5, "6": 6, "7": 7, "8": 8, "9": 9,
+	}
+
+	FullwidthFigures = Words{
+		"０": 0, "１": 1, "２": 2, "３": 3, "４": 4, "５": 5, "６": 6, "７": 7, "８": 8, "９": 9,
+	}
+
+	ArabicIndicFigures = Words{
+		"٠": 0, "١": 1, "٢": 2, "٣": 3, "٤": 4, "٥": 5, "٦": 6, "٧": 7, "٨": 8, "٩": 9,
+	}
+
+	English = Words{
+		"zero": 0, "one": 1, "two": 2, "three": 3, "four": 4,
+		"five": 5, "six": 6, "seven": 7, "eight": 8, "nine": 9,
+	}
+
+	French = Words{
+		"zéro": 0, "un": 1, "deux": 2, "trois": 3, "quatre": 4,
+		"cinq": 5, "six": 6, "sept": 7, "huit": 8, "neuf": 9,
+	}
+
+	German = Words{
+		"null": 0, "eins": 1, "zwei": 2, "drei": 3, "vier": 4,
+		"fünf": 5, "sechs": 6, "sieben": 7, "acht": 8, "neun": 9,
+	}
+
+	Spanish = Words{
+		"cero": 0, "uno": 1, "dos": 2, "tres": 3, "cuatro": 4,
+		"cinco": 5, "seis": 6, "siete": 7, "ocho": 8, "nueve": 9,
+	}
+)
+
+func MergeWords(wordLists ...Words) Words {
+	words := Words{}
+	for _, wordList := range wordLists {
+		maps.Copy(words, wordList)
+	}
+
+	return words
+}
+
+/*
+A Vocabulary is a trie of every way a digit can be written, compiled once.
+Looking for the first digit of a line walks the trie from each position
+starting from the left and stops at the first position where a word ends,
+so overlapping words like "oneight" are read as 1 then 8 without slicing
+the line or iterating a map. The last digit is found the same way,
+starting from the right.
+
+The trie works on UTF-8 bytes: a word always starts with a leading byte,
+so no match can start in the middle of a multi-byte character.
+
+A case insensitive vocabulary stores the words with every rune folded to
+the smallest rune of its case folding orbit, and folds the runes of the
+line the same way while walking, so "six", "SIX" and "ſix" share a path.
+*/
+
+type vocabularyNode struct {
+	// Index of the child node for each byte, 0 meaning no child
+	// since the root is never a child.
+	next [256]int32
+	// Value of the digit if a word ends at this node, -1 otherwise
+	value int
+}
+
+type Vocabulary struct {
+	nodes    []vocabularyNode
+	foldCase bool
+}
+
+func NewVocabulary(words Words) *Vocabulary {
+	vocabulary := &Vocabulary{}
+	vocabulary.nodes = append(vocabulary.nodes, vocabularyNode{value: -1})
+
+	for word, value := range words {
+		vocabulary.insert(word, value)
+	}
+
+	return vocabulary
+}
+
+// NewCaseInsensitiveVocabulary matches the words whatever their case,
+// including the case variants outside of ASCII like "ſ" for "s".
+func NewCaseInsensitiveVocabulary(words Words) *Vocabulary {
+	vocabulary := NewVocabulary(nil)
+	vocabulary.foldCase = true
+
+	for word, value := range words {
+		vocabulary.insert(strings.Map(foldRune, word), value)
+	}
+
+	return vocabulary
+}
+
+// foldRune returns the smallest rune equivalent to r under case folding,
+// so that every case variant of a word folds to the same spelling.
+func foldRune(r rune) rune {
+	if r < utf8.RuneSelf {
+		if 'a' <= r && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		return r
+	}
+
+	// SimpleFold iterates over the runes equivalent to r under case folding
+	smallest := r
+	for folded := unicode.SimpleFold(r); folded != r; folded = unicode.SimpleFold(folded) {
+		smallest = min(smallest, folded)
+	}
+
+	return smallest
+}
+
+func (vocabulary *Vocabulary) insert(word string, value int) {
+	current := 0
+	for i := 0; i < len(word); i++ {
+		next := vocabulary.nodes[current].next[word[i]]
+		if next == 0 {
+			vocabulary.nodes = append(vocabulary.nodes, vocabularyNode{value: -1})
+			next = int32(len(vocabulary.nodes) - 1)
+			vocabulary.nodes[current].next[word[i]] = next
+		}
+		current = int(next)
+	}
+	vocabulary.nodes[current].value = value
+}
+
+type TokenKind int
+
+const (
+	// A digit written with a figure, like "7" or "٧"
+	FigureToken TokenKind = iota
+	// A digit spelled out with letters, like "seven"
+	WordToken
+)
+
+func (kind TokenKind) String() string {
+	if kind == FigureToken {
+		return "figure"
+	}
+
+	return "word"
+}
+
+// Token is a digit found on a line, Offset being the index of its first byte.
+type Token struct {
+	Value  int
+	Text   string
+	Offset int
+	Kind   TokenKind
+}
+
+// MatchAt returns the value of the longest word starting at index i of line.
+func (vocabulary *Vocabulary) MatchAt(line string, i int) (int, bool) {
+	value, _ := vocabulary.matchAt(line, i)
+
+	return value, value != -1
+}
+
+// matchAt returns the value and length of the longest word starting
+// at index i of line, or -1 if none does.
+func (vocabulary *Vocabulary) matchAt(line string, i int) (int, int) {
+	if vocabulary.foldCase {
+		return vocabulary.matchFoldedAt(line, i)
+	}
+
+	value, length := -1, 0
+
+	current := 0
+	for j := i; j < len(line); j++ {
+		current = int(vocabulary.nodes[current].next[line[j]])
+		if current == 0 {
+			break
+		}
+		if vocabulary.nodes[current].value != -1 {
+			value, length = vocabulary.nodes[current].value, j-i+1
+		}
+	}
+
+	return value, length
+}
+
+// matchFoldedAt is matchAt for case insensitive vocabularies, walking the
+// trie with the folded runes of the line. The length is still the one of
+// the word as written on the line, since folding can change it.
+func (vocabulary *Vocabulary) matchFoldedAt(line string, i int) (int, int) {
+	value, length := -1, 0
+
+	current := 0
+	buffer := [utf8.UTFMax]byte{}
+	for j := i; j < len(line); {
+		r, size := utf8.DecodeRuneInString(line[j:])
+
+		// Invalid bytes are walked as they are, like in matchAt
+		folded := buffer[:1]
+		if r == utf8.RuneError && size == 1 {
+			folded[0] = line[j]
+		} else {
+			folded = buffer[:utf8.EncodeRune(buffer[:], foldRune(r))]
+		}
+
+		for _, b := range folded {
+			current = int(vocabulary.nodes[current].next[b])
+			if current == 0 {
+				return value, length
+			}
+		}
+
+		j += size
+		if vocabulary.nodes[current].value != -1 {
+			value, length = vocabulary.nodes[current].value, j-i
+		}
+	}
+
+	return value, length
+}
+
+func (vocabulary *Vocabulary) tokenAt(line string, i int) (Token, bool) {
+	value, length := vocabulary.matchAt(line, i)
+	if value == -1 {
+		return Token{}, false
+	}
+
+	token := Token{Value: value, Text: line[i : i+length], Offset: i, Kind: WordToken}
+
+	if r, _ := utf8.DecodeRuneInString(token.Text); unicode.IsDigit(r) {
+		token.Kind = FigureToken
+	}
+
+	return token, true
+}
+
+func (vocabulary *Vocabulary) First(line string) (int, bool) {
+	token, ok := vocabulary.FirstToken(line)
+
+	return token.Value, ok
+}
+
+func (vocabulary *Vocabulary) Last(line string) (int, bool) {
+	token, ok := vocabulary.LastToken(line)
+
+	return token.Value, ok
+}
+
+func (vocabulary *Vocabulary) FirstToken(line string) (Token, bool) {
+	for i := 0; i < len(line); i++ {
+		if token, ok := vocabulary.tokenAt(line, i); ok {
+			return token, true
+		}
+	}
+
+	return Token{}, false
+}
+
+func (vocabulary *Vocabulary) LastToken(line string) (Token, bool) {
+	for i := len(line) - 1; i >= 0; i-- {
+		if token, ok := vocabulary.tokenAt(line, i); ok {
+			return token, true
+		}
+	}
+
+	return Token{}, false
+}
@@ -0,0 +1,235 @@
+package day01
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVocabulary(t *testing.T) {
+	type test struct {
+		line      string
+		wantFirst int
+		wantLast  int
+	}
+
+	tests := []test{
+		{"oneight", 1, 8},
+		{"twone", 2, 1},
+		{"eightwothree", 8, 3},
+		{"xtwone3four", 2, 4},
+		{"sevenine", 7, 9},
+		{"7pqrstsixteen", 7, 6},
+		{"zerone", 0, 1},
+		{"5", 5, 5},
+	}
+
+	vocabulary := NewVocabulary(MergeWords(Figures, English))
+
+	for _, tc := range tests {
+		first, ok := vocabulary.First(tc.line)
+		assert.True(t, ok)
+		assert.Equal(t, tc.wantFirst, first, tc.line)
+
+		last, ok := vocabulary.Last(tc.line)
+		assert.True(t, ok)
+		assert.Equal(t, tc.wantLast, last, tc.line)
+	}
+
+	_, ok := vocabulary.First("abcdef")
+	assert.False(t, ok)
+}
+
+func TestVocabularyLongestWord(t *testing.T) {
+	vocabulary := NewVocabulary(Words{"six": 6, "sixteen": 16})
+
+	value, ok := vocabulary.MatchAt("sixteen", 0)
+	assert.True(t, ok)
+	assert.Equal(t, 16, value)
+
+	value, ok = vocabulary.MatchAt("sixtee", 0)
+	assert.True(t, ok)
+	assert.Equal(t, 6, value)
+}
+
+func TestVocabularyLanguages(t *testing.T) {
+	type test struct {
+		vocabulary *Vocabulary
+		line       string
+		wantFirst  int
+		wantLast   int
+	}
+
+	tests := []test{
+		{NewVocabulary(French), "cinquatre", 5, 4},
+		{NewVocabulary(French), "xseptroisx", 7, 3},
+		{NewVocabulary(French), "zérounz", 0, 1},
+		{NewVocabulary(German), "zweins", 2, 1},
+		{NewVocabulary(German), "einsiebenfünf", 1, 5},
+		{NewVocabulary(German), "achtzwein", 8, 2},
+		{NewVocabulary(Spanish), "dosiete", 2, 7},
+		{NewVocabulary(Spanish), "tresietecincuno", 3, 1},
+		{NewVocabulary(Spanish), "nueveintiuno", 9, 1},
+		{NewVocabulary(FullwidthFigures), "a１b２c３", 1, 3},
+		{NewVocabulary(ArabicIndicFigures), "x٣y٧", 3, 7},
+		{NewVocabulary(MergeWords(Figures, FullwidthFigures, ArabicIndicFigures)), "4٣z９", 4, 9},
+		{NewCaseInsensitiveVocabulary(English), "OneIGHT", 1, 8},
+		{NewCaseInsensitiveVocabulary(German), "FÜNFzweINS", 5, 1},
+		{NewCaseInsensitiveVocabulary(French), "ZÉROnEUF", 0, 9},
+		{NewCaseInsensitiveVocabulary(English), "ſIXſeveN", 6, 7},
+	}
+
+	for _, tc := range tests {
+		first, ok := tc.vocabulary.First(tc.line)
+		assert.True(t, ok, tc.line)
+		assert.Equal(t, tc.wantFirst, first, tc.line)
+
+		last, ok := tc.vocabulary.Last(tc.line)
+		assert.True(t, ok, tc.line)
+		assert.Equal(t, tc.wantLast, last, tc.line)
+	}
+
+	// Case sensitive by default
+	_, ok := NewVocabulary(English).First("ONE")
+	assert.False(t, ok)
+}
+
+func TestCaseInsensitiveVocabularySize(t *testing.T) {
+	// "s" and "k" both fold with two other runes, "ſ" and the Kelvin sign "K"
+	word := strings.Repeat("sk", 15)
+	vocabulary := NewCaseInsensitiveVocabulary(Words{word: 1})
+
+	// One node per byte of the word, not one per case variant
+	assert.Len(t, vocabulary.nodes, len(word)+1)
+
+	line := "x" + strings.Repeat("ſ\u212A", 5) + strings.Repeat("Sk", 10) + "x"
+	token, ok := vocabulary.FirstToken(line)
+	assert.True(t, ok)
+	assert.Equal(t, Token{Value: 1, Text: line[1 : len(line)-1], Offset: 1, Kind: WordToken}, token)
+}
+
+func TestComputeCalibrationSumWithVocabulary(t *testing.T) {
+	input := CalibrationInput{"deuxtrois", "unhuitx7", "Neuf"}
+	options := CalibrationOptions{Vocabulary: NewCaseInsensitiveVocabulary(MergeWords(Figures, French))}
+
+	assert.Equal(t, 23+17+99, ComputeCalibrationSum(input, options))
+}
+
+func TestComputeLineCalibrationOverlaps(t *testing.T) {
+	type test struct {
+		line string
+		want int
+	}
+
+	// Spelled out digits can share letters, the last one still counts
+	tests := []test{
+		{line: "oneight", want: 18},
+		{line: "twone", want: 21},
+		{line: "threeight", want: 38},
+		{line: "fiveight", want: 58},
+		{line: "sevenine", want: 79},
+		{line: "eightwo", want: 82},
+		{line: "eighthree", want: 83},
+		{line: "nineight", want: 98},
+		{line: "nnine", want: 99},
+		{line: "oneeight2twone", want: 11},
+		{line: "xtwone3four", want: 24},
+		{line: "zzfivezz", want: 55},
+		{line: "ninine", want: 99},
+		{line: "sevenineight", want: 78},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, ComputeLineCalibration(tc.line, Part2Options), tc.line)
+	}
+}
+
+// naiveLineCalibration is the original implementation, checking every
+// spelled out digit at every position, kept to compare the matcher against.
+func naiveLineCalibration(line string) int {
+	digitsOnTheLine := []rune{}
+	for i, char := range line {
+		if utils.IsRuneADigit(char) {
+			if len(digitsOnTheLine) < 2 {
+				digitsOnTheLine = append(digitsOnTheLine, char)
+			} else {
+				digitsOnTheLine[1] = char
+			}
+		} else {
+			for spelledOutDigit, intValue := range English {
+				if i+len(spelledOutDigit) > len(line) {
+					continue
+				}
+				possibleDigit := line[i : i+len(spelledOutDigit)]
+				if possibleDigit == spelledOutDigit {
+					if len(digitsOnTheLine) < 2 {
+						digitsOnTheLine = append(digitsOnTheLine, rune(intValue+'0'))
+					} else {
+						digitsOnTheLine[1] = rune(intValue + '0')
+					}
+				}
+			}
+		}
+	}
+
+	if len(digitsOnTheLine) == 1 {
+		digitsOnTheLine = append(digitsOnTheLine, digitsOnTheLine[0])
+	}
+
+	number, err := strconv.Atoi(string(digitsOnTheLine))
+	if err != nil {
+		panic(err)
+	}
+
+	return number
+}
+
+// generateCalibrationInput builds lines made of random letters likely to
+// form spelled out digits, with a digit in the middle of each line.
+func generateCalibrationInput(linesCount, lineLength int) CalibrationInput {
+	random := rand.New(rand.NewSource(2023))
+	letters := "abcefghinorstuvwxz"
+
+	input := CalibrationInput{}
+	for i := 0; i < linesCount; i++ {
+		line := make([]byte, lineLength)
+		for j := range line {
+			line[j] = letters[random.Intn(len(letters))]
+		}
+		line[lineLength/2] = byte('0' + random.Intn(10))
+
+		input = append(input, string(line))
+	}
+
+	return input
+}
+
+func TestComputeLineCalibrationMatchesNaive(t *testing.T) {
+	for _, line := range generateCalibrationInput(2000, 40) {
+		assert.Equal(t, naiveLineCalibration(line), ComputeLineCalibration(line, Part2Options), line)
+	}
+}
+
+func BenchmarkLargeInputNaive(b *testing.B) {
+	input := generateCalibrationInput(1000, 200)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, line := range input {
+			naiveLineCalibration(line)
+		}
+	}
+}
+
+func BenchmarkLargeInputMatcher(b *testing.B) {
+	input := generateCalibrationInput(1000, 200)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		ComputeCalibrationSum(input, Part2Options)
+	}
+}
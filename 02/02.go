@@ -1,23 +1,103 @@
-package main
+package day02
 
 import (
+	"embed"
+	"encoding/json"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 
 	"github.com/angristan/advent-of-code-2023/utils"
 )
 
-func main() {
-	input := utils.ParseInput("input.txt")
+//go:embed input.txt example*.txt
+var inputs embed.FS
 
-	gameSets := ConvertInput(input)
+// Run prints the answers of both parts under the configured bag. Its
+// subcommands filter the games with a query, write them back as text or
+// JSON, or explore them in a REPL.
+func Run(args []string) error {
+	config := DefaultConfig()
 
-	part1Sum := gameSets.ComputeIDSumOfPossibleGames()
-	fmt.Printf("Part 1: %d\n", part1Sum)
+	// parse reads the games for the subcommands
+	parse := func(input []string, run func(gameSets GameSetsInput) error) error {
+		gameSets, err := ConvertInput(input)
+		if err != nil {
+			return err
+		}
+
+		return run(gameSets)
+	}
 
-	part2Sum := gameSets.ComputeSumOfPowerOfMinimalGameSets()
-	fmt.Printf("Part 2: %d\n", part2Sum)
+	return utils.DayRunner{
+		Day:    "02",
+		Inputs: inputs,
+		Config: &config,
+		Lint:   LintInput,
+		Solve: func(input []string) error {
+			return parse(input, func(gameSets GameSetsInput) error {
+				part1Sum, err := gameSets.ComputeIDSumOfPossibleGamesWithBag(config.Bag)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("Part 1: %d\n", part1Sum)
+
+				part2Sum, err := gameSets.ComputeSumOfPowerOfMinimalGameSetsWithBag(config.Bag)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("Part 2: %d\n", part2Sum)
+
+				return nil
+			})
+		},
+		Subcommands: map[string]utils.Subcommand{
+			"filter": {
+				Usage: "filter <query>",
+				Run: func(input []string, options *utils.Options) error {
+					query, err := ParseQuery(strings.Join(options.Args()[1:], " "))
+					if err != nil {
+						return err
+					}
+
+					return parse(input, func(gameSets GameSetsInput) error {
+						for _, game := range gameSets.Filter(query) {
+							fmt.Println(game)
+						}
+						return nil
+					})
+				},
+			},
+			"format": {
+				Usage: "format",
+				Run: func(input []string, options *utils.Options) error {
+					return parse(input, func(gameSets GameSetsInput) error {
+						fmt.Println(gameSets)
+						return nil
+					})
+				},
+			},
+			"json": {
+				Usage: "json",
+				Run: func(input []string, options *utils.Options) error {
+					return parse(input, func(gameSets GameSetsInput) error {
+						encoder := json.NewEncoder(os.Stdout)
+						encoder.SetIndent("", "  ")
+						return encoder.Encode(gameSets)
+					})
+				},
+			},
+			"repl": {
+				Usage: "repl",
+				Run: func(input []string, options *utils.Options) error {
+					return parse(input, func(gameSets GameSetsInput) error {
+						return gameSets.REPL(config.Bag).Run(os.Stdin, os.Stdout)
+					})
+				},
+			},
+		},
+	}.Run(args)
 }
 
 /*
@@ -33,42 +113,118 @@ func main() {
 type CubeColor string
 type CubeSample map[CubeColor]int
 type GameSet []CubeSample
-type GameSetsInput []GameSet
 
-func ConvertInput(input []string) GameSetsInput {
+// Game is a game set along with the ID it was recorded with,
+// which isn't necessarily its position in the input.
+type Game struct {
+	ID int
+	GameSet
+}
+
+type GameSetsInput []Game
+
+func ConvertInput(input []string) (GameSetsInput, error) {
 	gameSets := GameSetsInput{}
 
-	for _, line := range input {
-		// drop "Game X: " prefixs
-		colonIndex := strings.Index(line, ":")
-		line = line[colonIndex+1:]
+	// seenOnLine maps game IDs to the line they were first seen on
+	seenOnLine := map[int]int{}
 
-		// split by ";" to get samples
-		gameSet := GameSet{}
-		for _, game := range strings.Split(line, ";") {
-			gameMap := CubeSample{}
-			for _, colorAndCount := range strings.Split(game, ",") {
-				colorAndCount = strings.TrimSpace(colorAndCount)
+	for i, line := range input {
+		game, err := ParseGame(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
 
-				// split by " " to get count and color
-				spaceIndex := strings.Index(colorAndCount, " ")
+		if firstLine, ok := seenOnLine[game.ID]; ok {
+			return nil, fmt.Errorf("line %d: duplicate game ID %d (first seen on line %d)", i+1, game.ID, firstLine)
+		}
+		seenOnLine[game.ID] = i + 1
 
-				countAsString := colorAndCount[:spaceIndex]
-				color := colorAndCount[spaceIndex+1:]
+		gameSets = append(gameSets, game)
+	}
 
-				count, err := strconv.Atoi(countAsString)
-				if err != nil {
-					panic(err)
-				}
-				gameMap[CubeColor(color)] = count
-			}
-			gameSet = append(gameSet, gameMap)
+	return gameSets, nil
+}
+
+func ParseGame(line string) (Game, error) {
+	game := Game{}
+
+	// split "Game X: " prefix from the samples
+	prefix, samples, ok := strings.Cut(line, ":")
+	if !ok {
+		return game, fmt.Errorf("expected \"Game <id>: <draws>\", got %q", line)
+	}
+
+	rawID, ok := strings.CutPrefix(prefix, "Game ")
+	if !ok {
+		return game, fmt.Errorf("expected \"Game <id>: <draws>\", got %q", line)
+	}
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil || id < 0 {
+		return game, fmt.Errorf("invalid game ID %q", rawID)
+	}
+	game.ID = id
+
+	game.GameSet, err = ParseGameSet(samples)
+	if err != nil {
+		return game, err
+	}
+
+	return game, nil
+}
+
+func ParseGameSet(text string) (GameSet, error) {
+	gameSet := GameSet{}
+
+	// A game without draws is written "Game N: "
+	if strings.TrimSpace(text) == "" {
+		return gameSet, nil
+	}
+
+	// split by ";" to get samples
+	for _, rawSample := range strings.Split(text, ";") {
+		sample, err := ParseCubeSample(rawSample)
+		if err != nil {
+			return nil, err
+		}
+		gameSet = append(gameSet, sample)
+	}
+
+	return gameSet, nil
+}
+
+func ParseCubeSample(text string) (CubeSample, error) {
+	sample := CubeSample{}
+
+	for _, colorAndCount := range strings.Split(text, ",") {
+		colorAndCount = strings.TrimSpace(colorAndCount)
+
+		// split by " " to get count and color
+		countAsString, color, ok := strings.Cut(colorAndCount, " ")
+		if !ok {
+			return nil, fmt.Errorf("expected \"<count> <color>\", got %q", colorAndCount)
 		}
 
-		gameSets = append(gameSets, gameSet)
+		count, err := strconv.Atoi(countAsString)
+		if err != nil {
+			return nil, fmt.Errorf("invalid count in %q", colorAndCount)
+		}
+		sample[CubeColor(color)] = count
+	}
+
+	return sample, nil
+}
+
+// Lookup finds the game recorded with id.
+func (gameSets GameSetsInput) Lookup(id int) (Game, bool) {
+	for _, game := range gameSets {
+		if game.ID == id {
+			return game, true
+		}
 	}
 
-	return gameSets
+	return Game{}, false
 }
 
 /*
@@ -77,35 +233,49 @@ func ConvertInput(input []string) GameSetsInput {
 	What is the sum of the IDs of those games?
 */
 
-var (
-	targetCubeAvailability = map[CubeColor]int{
-		"red":   12,
-		"green": 13,
-		"blue":  14,
-	}
-)
+// ComputeIDSumOfPossibleGames fails if a color drawn during a game isn't in
+// the DefaultBag.
+func (gameSets GameSetsInput) ComputeIDSumOfPossibleGames() (int, error) {
+	return gameSets.ComputeIDSumOfPossibleGamesWithBag(DefaultBag)
+}
 
-func (gameSets GameSetsInput) ComputeIDSumOfPossibleGames() int {
+func (gameSets GameSetsInput) ComputeIDSumOfPossibleGamesWithBag(bag Bag) (int, error) {
 	sum := 0
 
-	for id, gameSet := range gameSets {
-		isPossible := true
-		for _, sampleGame := range gameSet {
-			for color, count := range sampleGame {
-				if count > targetCubeAvailability[color] {
-					isPossible = false
-					break
-				}
-			}
+	for _, game := range gameSets {
+		possible, err := game.IsPossibleWithBag(bag)
+		if err != nil {
+			return 0, fmt.Errorf("game %d: %w", game.ID, err)
+		}
 
+		if possible {
+			sum += game.ID
 		}
+	}
+
+	return sum, nil
+}
+
+// IsPossible fails if a color drawn during the game isn't in the DefaultBag.
+func (gameSet GameSet) IsPossible() (bool, error) {
+	return gameSet.IsPossibleWithBag(DefaultBag)
+}
+
+// IsPossibleWithBag fails if a color drawn during the game isn't in the bag.
+func (gameSet GameSet) IsPossibleWithBag(bag Bag) (bool, error) {
+	if err := bag.CheckColors(gameSet); err != nil {
+		return false, err
+	}
 
-		if isPossible {
-			sum += id + 1
+	for _, sampleGame := range gameSet {
+		for color, count := range sampleGame {
+			if count > bag[color] {
+				return false, nil
+			}
 		}
 	}
 
-	return sum
+	return true, nil
 }
 
 /*
@@ -140,19 +310,40 @@ func (gameSet GameSet) ComputeMinimumGameSet() MinimumGameSet {
 	cubes multiplied together.
 */
 
-func (gameSets GameSetsInput) ComputeSumOfPowerOfMinimalGameSets() int {
-	sum := 0
+// ComputeSumOfPowerOfMinimalGameSets fails if a color drawn during a game
+// isn't in the DefaultBag.
+func (gameSets GameSetsInput) ComputeSumOfPowerOfMinimalGameSets() (int, error) {
+	return gameSets.ComputeSumOfPowerOfMinimalGameSetsWithBag(DefaultBag)
+}
 
-	for _, gameSet := range gameSets {
-		minimumGameSet := gameSet.ComputeMinimumGameSet()
+func (gameSets GameSetsInput) ComputeSumOfPowerOfMinimalGameSetsWithBag(bag Bag) (int, error) {
+	sum := 0
 
-		power := 1
-		for _, count := range minimumGameSet {
-			power *= count
+	for _, game := range gameSets {
+		power, err := game.PowerWithBag(bag)
+		if err != nil {
+			return 0, fmt.Errorf("game %d: %w", game.ID, err)
 		}
 
 		sum += power
 	}
 
-	return sum
+	return sum, nil
+}
+
+// PowerWithBag multiplies the minimum count of every color of the bag,
+// so a color never drawn during the game makes the power 0.
+func (gameSet GameSet) PowerWithBag(bag Bag) (int, error) {
+	if err := bag.CheckColors(gameSet); err != nil {
+		return 0, err
+	}
+
+	minimumGameSet := gameSet.ComputeMinimumGameSet()
+
+	power := 1
+	for color := range bag {
+		power *= minimumGameSet[color]
+	}
+
+	return power, nil
 }
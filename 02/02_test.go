@@ -1,4 +1,4 @@
-package main
+package day02
 
 import (
 	"testing"
@@ -22,41 +22,78 @@ func TestConvertInput(t *testing.T) {
 				"Game 5: 6 red, 1 blue, 3 green; 2 blue, 1 red, 2 green",
 			},
 			GameSetsInput{
-				{
+				{ID: 1, GameSet: GameSet{
 					{"blue": 3, "red": 4},
 					{"red": 1, "green": 2, "blue": 6},
 					{"green": 2},
-				},
-				{
+				}},
+				{ID: 2, GameSet: GameSet{
 					{"blue": 1, "green": 2},
 					{"green": 3, "blue": 4, "red": 1},
 					{"green": 1, "blue": 1},
-				},
-				{
+				}},
+				{ID: 3, GameSet: GameSet{
 					{"green": 8, "blue": 6, "red": 20},
 					{"blue": 5, "red": 4, "green": 13},
 					{"green": 5, "red": 1},
-				},
-				{
+				}},
+				{ID: 4, GameSet: GameSet{
 					{"green": 1, "red": 3, "blue": 6},
 					{"green": 3, "red": 6},
 					{"green": 3, "blue": 15, "red": 14},
-				},
-				{
+				}},
+				{ID: 5, GameSet: GameSet{
 					{"red": 6, "blue": 1, "green": 3},
 					{"blue": 2, "red": 1, "green": 2},
-				},
+				}},
 			},
 		},
 	}
 
 	for _, tc := range tests {
-		got := ConvertInput(tc.input)
+		got, err := ConvertInput(tc.input)
 
+		assert.NoError(t, err)
 		assert.Equal(t, tc.want, got)
 	}
 }
 
+func TestConvertInputErrors(t *testing.T) {
+	type test struct {
+		input []string
+		want  string
+	}
+
+	tests := []test{
+		{
+			[]string{"Game 1: 3 blue", "Game 2 4 red"},
+			`line 2: expected "Game <id>: <draws>", got "Game 2 4 red"`,
+		},
+		{
+			[]string{"Round 1: 3 blue"},
+			`line 1: expected "Game <id>: <draws>", got "Round 1: 3 blue"`,
+		},
+		{
+			[]string{"Game one: 3 blue"},
+			`line 1: invalid game ID "one"`,
+		},
+		{
+			[]string{"Game 1: 3 blue, red"},
+			`line 1: expected "<count> <color>", got "red"`,
+		},
+		{
+			[]string{"Game 1: 3 blue", "Game 7: 1 red", "Game 1: 2 green"},
+			"line 3: duplicate game ID 1 (first seen on line 1)",
+		},
+	}
+
+	for _, tc := range tests {
+		_, err := ConvertInput(tc.input)
+
+		assert.EqualError(t, err, tc.want)
+	}
+}
+
 func TestComputeIDSumOfPossibleGames(t *testing.T) {
 	type test struct {
 		input GameSetsInput
@@ -66,38 +103,90 @@ func TestComputeIDSumOfPossibleGames(t *testing.T) {
 	tests := []test{
 		{
 			GameSetsInput{
-				{
+				{ID: 1, GameSet: GameSet{
 					{"blue": 3, "red": 4},
 					{"red": 1, "green": 2, "blue": 6},
 					{"green": 2},
-				},
-				{
+				}},
+				{ID: 2, GameSet: GameSet{
 					{"blue": 1, "green": 2},
 					{"green": 3, "blue": 4, "red": 1},
 					{"green": 1, "blue": 1},
-				},
-				{
+				}},
+				{ID: 3, GameSet: GameSet{
 					{"green": 8, "blue": 6, "red": 20},
 					{"blue": 5, "red": 4, "green": 13},
 					{"green": 5, "red": 1},
-				},
-				{
+				}},
+				{ID: 4, GameSet: GameSet{
 					{"green": 1, "red": 3, "blue": 6},
 					{"green": 3, "red": 6},
 					{"green": 3, "blue": 15, "red": 14},
-				},
-				{
+				}},
+				{ID: 5, GameSet: GameSet{
 					{"red": 6, "blue": 1, "green": 3},
 					{"blue": 2, "red": 1, "green": 2},
-				},
+				}},
 			},
 			8,
 		},
+		{
+			// Filtered and reordered games keep their own IDs
+			GameSetsInput{
+				{ID: 5, GameSet: GameSet{
+					{"red": 6, "blue": 1, "green": 3},
+					{"blue": 2, "red": 1, "green": 2},
+				}},
+				{ID: 3, GameSet: GameSet{
+					{"green": 8, "blue": 6, "red": 20},
+					{"blue": 5, "red": 4, "green": 13},
+					{"green": 5, "red": 1},
+				}},
+				{ID: 42, GameSet: GameSet{
+					{"blue": 3, "red": 4},
+				}},
+			},
+			47,
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := tc.input.ComputeIDSumOfPossibleGames()
+
+		assert.NoError(t, err)
+		assert.Equal(t, tc.want, got)
+	}
+}
+
+func TestIsPossible(t *testing.T) {
+	type test struct {
+		input GameSet
+		want  bool
+	}
+
+	tests := []test{
+		{
+			GameSet{
+				{"blue": 3, "red": 4},
+				{"red": 1, "green": 2, "blue": 6},
+				{"green": 2},
+			},
+			true,
+		},
+		{
+			GameSet{
+				{"green": 8, "blue": 6, "red": 20},
+				{"blue": 5, "red": 4, "green": 13},
+				{"green": 5, "red": 1},
+			},
+			false,
+		},
 	}
 
 	for _, tc := range tests {
-		got := tc.input.ComputeIDSumOfPossibleGames()
+		got, err := tc.input.IsPossible()
 
+		assert.NoError(t, err)
 		assert.Equal(t, tc.want, got)
 	}
 }
@@ -186,38 +275,39 @@ func TestComputeSumOfPowerOfMinimalGameSets(t *testing.T) {
 	tests := []test{
 		{
 			GameSetsInput{
-				{
+				{ID: 1, GameSet: GameSet{
 					{"blue": 3, "red": 4},
 					{"red": 1, "green": 2, "blue": 6},
 					{"green": 2},
-				},
-				{
+				}},
+				{ID: 2, GameSet: GameSet{
 					{"blue": 1, "green": 2},
 					{"green": 3, "blue": 4, "red": 1},
 					{"green": 1, "blue": 1},
-				},
-				{
+				}},
+				{ID: 3, GameSet: GameSet{
 					{"green": 8, "blue": 6, "red": 20},
 					{"blue": 5, "red": 4, "green": 13},
 					{"green": 5, "red": 1},
-				},
-				{
+				}},
+				{ID: 4, GameSet: GameSet{
 					{"green": 1, "red": 3, "blue": 6},
 					{"green": 3, "red": 6},
 					{"green": 3, "blue": 15, "red": 14},
-				},
-				{
+				}},
+				{ID: 5, GameSet: GameSet{
 					{"red": 6, "blue": 1, "green": 3},
 					{"blue": 2, "red": 1, "green": 2},
-				},
+				}},
 			},
 			2286,
 		},
 	}
 
 	for _, tc := range tests {
-		got := tc.input.ComputeSumOfPowerOfMinimalGameSets()
+		got, err := tc.input.ComputeSumOfPowerOfMinimalGameSets()
 
+		assert.NoError(t, err)
 		assert.Equal(t, tc.want, got)
 	}
 }
@@ -0,0 +1,67 @@
+package day02
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Bag lists every cube color that can be drawn along with how many cubes
+// of that color were loaded. Drawing a color that isn't listed is an error.
+type Bag map[CubeColor]int
+
+// DefaultBag is the bag of the puzzle: 12 red cubes, 13 green cubes,
+// and 14 blue cubes.
+var DefaultBag = Bag{
+	"red":   12,
+	"green": 13,
+	"blue":  14,
+}
+
+func (bag Bag) Validate() error {
+	if len(bag) == 0 {
+		return errors.New("bag has no colors")
+	}
+
+	for color, count := range bag {
+		if count < 0 {
+			return fmt.Errorf("bag has a negative count of %s cubes: %d", color, count)
+		}
+	}
+
+	return nil
+}
+
+// CheckColors returns an error naming the first color of the game,
+// in alphabetical order, that isn't in the bag.
+func (bag Bag) CheckColors(gameSet GameSet) error {
+	unknown := []CubeColor{}
+
+	for _, sample := range gameSet {
+		for color := range sample {
+			if _, ok := bag[color]; !ok {
+				unknown = append(unknown, color)
+			}
+		}
+	}
+
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("unknown color %q", slices.Min(unknown))
+}
+
+// UnmarshalJSON replaces the colors of the bag rather than merging them,
+// since the bag lists every color that can be drawn.
+func (bag *Bag) UnmarshalJSON(data []byte) error {
+	colors := map[CubeColor]int{}
+	if err := json.Unmarshal(data, &colors); err != nil {
+		return err
+	}
+
+	*bag = colors
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package day02
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBagValidate(t *testing.T) {
+	assert.NoError(t, DefaultBag.Validate())
+	assert.EqualError(t, Bag{}.Validate(), "bag has no colors")
+	assert.EqualError(t, Bag{"red": -1}.Validate(), "bag has a negative count of red cubes: -1")
+}
+
+func TestBagUnmarshalJSON(t *testing.T) {
+	config := DefaultConfig()
+	assert.NoError(t, json.Unmarshal([]byte(`{"bag": {"red": 3, "yellow": 4}}`), &config))
+
+	// Colors left out of the configuration are no longer in the bag
+	assert.Equal(t, Bag{"red": 3, "yellow": 4}, config.Bag)
+	assert.Equal(t, 12, DefaultBag["red"])
+}
+
+func TestIsPossibleWithBag(t *testing.T) {
+	type test struct {
+		bag     Bag
+		want    bool
+		wantErr string
+	}
+
+	gameSet := GameSet{
+		{"blue": 3, "red": 4},
+		{"red": 1, "green": 2, "blue": 6},
+	}
+
+	tests := []test{
+		{Bag{"red": 4, "green": 2, "blue": 6}, true, ""},
+		{Bag{"red": 4, "green": 2, "blue": 5}, false, ""},
+		{Bag{"red": 4, "green": 2, "blue": 6, "yellow": 0}, true, ""},
+		{Bag{"red": 12, "blue": 14}, false, `unknown color "green"`},
+	}
+
+	for _, tc := range tests {
+		got, err := gameSet.IsPossibleWithBag(tc.bag)
+
+		if tc.wantErr != "" {
+			assert.EqualError(t, err, tc.wantErr)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, tc.want, got)
+	}
+}
+
+func TestPowerWithBag(t *testing.T) {
+	type test struct {
+		input   GameSet
+		bag     Bag
+		want    int
+		wantErr string
+	}
+
+	tests := []test{
+		{
+			GameSet{{"blue": 3, "red": 4}, {"red": 1, "green": 2, "blue": 6}},
+			DefaultBag,
+			48,
+			"",
+		},
+		{
+			// No green cube was ever drawn
+			GameSet{{"blue": 3, "red": 4}, {"red": 1, "blue": 6}},
+			DefaultBag,
+			0,
+			"",
+		},
+		{
+			GameSet{{"blue": 3, "red": 4}, {"red": 1, "blue": 6}},
+			Bag{"red": 12, "blue": 14},
+			24,
+			"",
+		},
+		{
+			GameSet{{"blue": 3, "purple": 4}},
+			DefaultBag,
+			0,
+			`unknown color "purple"`,
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := tc.input.PowerWithBag(tc.bag)
+
+		if tc.wantErr != "" {
+			assert.EqualError(t, err, tc.wantErr)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, tc.want, got)
+	}
+}
+
+func TestComputeWithBagUnknownColor(t *testing.T) {
+	gameSets := GameSetsInput{
+		{ID: 1, GameSet: GameSet{{"blue": 3, "red": 4}}},
+		{ID: 7, GameSet: GameSet{{"blue": 3, "yellow": 4}}},
+	}
+
+	_, err := gameSets.ComputeIDSumOfPossibleGamesWithBag(DefaultBag)
+	assert.EqualError(t, err, `game 7: unknown color "yellow"`)
+
+	_, err = gameSets.ComputeSumOfPowerOfMinimalGameSetsWithBag(DefaultBag)
+	assert.EqualError(t, err, `game 7: unknown color "yellow"`)
+
+	// The names without a bag use the DefaultBag
+	_, err = gameSets.ComputeIDSumOfPossibleGames()
+	assert.EqualError(t, err, `game 7: unknown color "yellow"`)
+
+	_, err = gameSets.ComputeSumOfPowerOfMinimalGameSets()
+	assert.EqualError(t, err, `game 7: unknown color "yellow"`)
+
+	_, err = gameSets[1].IsPossible()
+	assert.EqualError(t, err, `unknown color "yellow"`)
+
+	bag := Bag{"red": 4, "blue": 3, "yellow": 3}
+
+	sum, err := gameSets.ComputeIDSumOfPossibleGamesWithBag(bag)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sum)
+
+	// Each game misses one of the colors of the bag
+	sum, err = gameSets.ComputeSumOfPowerOfMinimalGameSetsWithBag(bag)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sum)
+}
@@ -0,0 +1,19 @@
+package day02
+
+import (
+	"maps"
+)
+
+type Config struct {
+	Bag Bag `json:"bag"`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Bag: maps.Clone(DefaultBag),
+	}
+}
+
+func (config Config) Validate() error {
+	return config.Bag.Validate()
+}
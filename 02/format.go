@@ -0,0 +1,179 @@
+package day02
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+/*
+	Games are written back in the format of the puzzle input, with the colors
+	of each draw in alphabetical order so the output doesn't depend on map
+	iteration order:
+
+	Game 1: 3 blue, 4 red; 6 blue, 2 green, 1 red; 2 green
+
+	In JSON, a game is an object holding its ID and its draws:
+
+	{"id": 1, "draws": [{"blue": 3, "red": 4}, {"blue": 6, "green": 2, "red": 1}, {"green": 2}]}
+*/
+
+func (sample CubeSample) String() string {
+	return formatCubeCounts(sample)
+}
+
+func (sample CubeSample) MarshalText() ([]byte, error) {
+	return []byte(sample.String()), nil
+}
+
+func (sample *CubeSample) UnmarshalText(text []byte) error {
+	parsed, err := ParseCubeSample(string(text))
+	if err != nil {
+		return err
+	}
+
+	*sample = parsed
+	return nil
+}
+
+func (gameSet GameSet) String() string {
+	samples := make([]string, 0, len(gameSet))
+	for _, sample := range gameSet {
+		samples = append(samples, sample.String())
+	}
+
+	return strings.Join(samples, "; ")
+}
+
+func (gameSet GameSet) MarshalText() ([]byte, error) {
+	return []byte(gameSet.String()), nil
+}
+
+func (gameSet *GameSet) UnmarshalText(text []byte) error {
+	parsed, err := ParseGameSet(string(text))
+	if err != nil {
+		return err
+	}
+
+	*gameSet = parsed
+	return nil
+}
+
+func (game Game) String() string {
+	return fmt.Sprintf("Game %d: %s", game.ID, game.GameSet)
+}
+
+func (game Game) MarshalText() ([]byte, error) {
+	return []byte(game.String()), nil
+}
+
+func (game *Game) UnmarshalText(text []byte) error {
+	parsed, err := ParseGame(string(text))
+	if err != nil {
+		return err
+	}
+
+	*game = parsed
+	return nil
+}
+
+// String writes one game per line, without a trailing newline.
+func (gameSets GameSetsInput) String() string {
+	lines := make([]string, 0, len(gameSets))
+	for _, game := range gameSets {
+		lines = append(lines, game.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (gameSets GameSetsInput) MarshalText() ([]byte, error) {
+	return []byte(gameSets.String()), nil
+}
+
+func (gameSets *GameSetsInput) UnmarshalText(text []byte) error {
+	lines := []string{}
+	if trimmed := strings.TrimSuffix(string(text), "\n"); trimmed != "" {
+		lines = strings.Split(trimmed, "\n")
+	}
+
+	parsed, err := ConvertInput(lines)
+	if err != nil {
+		return err
+	}
+
+	*gameSets = parsed
+	return nil
+}
+
+// jsonGame is the JSON layout of a game. Draws are plain maps, which
+// encoding/json writes with sorted keys, rather than text.
+type jsonGame struct {
+	ID    int                 `json:"id"`
+	Draws []map[CubeColor]int `json:"draws"`
+}
+
+func (game Game) MarshalJSON() ([]byte, error) {
+	draws := make([]map[CubeColor]int, 0, len(game.GameSet))
+	for _, sample := range game.GameSet {
+		draws = append(draws, sample)
+	}
+
+	return json.Marshal(jsonGame{ID: game.ID, Draws: draws})
+}
+
+func (game *Game) UnmarshalJSON(data []byte) error {
+	decoded := jsonGame{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	game.ID = decoded.ID
+	game.GameSet = make(GameSet, 0, len(decoded.Draws))
+	for _, draw := range decoded.Draws {
+		game.GameSet = append(game.GameSet, draw)
+	}
+
+	return nil
+}
+
+// MarshalJSON writes the games as a JSON array rather than as text.
+func (gameSets GameSetsInput) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]Game(gameSets))
+}
+
+func (gameSets *GameSetsInput) UnmarshalJSON(data []byte) error {
+	games := []Game{}
+	if err := json.Unmarshal(data, &games); err != nil {
+		return err
+	}
+
+	seen := map[int]bool{}
+	for _, game := range games {
+		if seen[game.ID] {
+			return fmt.Errorf("duplicate game ID %d", game.ID)
+		}
+		seen[game.ID] = true
+	}
+
+	*gameSets = games
+	return nil
+}
+
+// formatCubeCounts prints colors in alphabetical order so the output
+// doesn't depend on map iteration order.
+func formatCubeCounts[M ~map[CubeColor]int](counts M) string {
+	colors := make([]CubeColor, 0, len(counts))
+	for color := range counts {
+		colors = append(colors, color)
+	}
+	slices.Sort(colors)
+
+	parts := make([]string, 0, len(colors))
+	for _, color := range colors {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[color], color))
+	}
+
+	return strings.Join(parts, ", ")
+}
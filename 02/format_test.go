@@ -0,0 +1,107 @@
+package day02
+
+import (
+	"encoding/json"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGameString(t *testing.T) {
+	game, err := ParseGame("Game 12: 3 blue, 4 red; 1 red, 2 green, 6 blue; 2 green")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Game 12: 3 blue, 4 red; 6 blue, 2 green, 1 red; 2 green", game.String())
+	assert.Equal(t, "6 blue, 2 green, 1 red", game.GameSet[1].String())
+
+	data, err := json.Marshal(game)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id": 12, "draws": [{"blue": 3, "red": 4}, {"blue": 6, "green": 2, "red": 1}, {"green": 2}]}`, string(data))
+
+	gameSets := GameSetsInput{}
+	assert.EqualError(t, json.Unmarshal([]byte(`[{"id": 1, "draws": []}, {"id": 1, "draws": []}]`), &gameSets), "duplicate game ID 1")
+}
+
+func TestGameWithoutDraws(t *testing.T) {
+	game := Game{ID: 4, GameSet: GameSet{}}
+	assert.Equal(t, "Game 4: ", game.String())
+
+	for _, line := range []string{"Game 4: ", "Game 4:"} {
+		parsed, err := ParseGame(line)
+		assert.NoError(t, err, line)
+		assert.Equal(t, game, parsed, line)
+	}
+
+	parsed := Game{}
+	assert.NoError(t, parsed.UnmarshalText([]byte(game.String())))
+	assert.Equal(t, game, parsed)
+
+	// An empty draw among others is still an error
+	_, err := ParseGame("Game 4: 1 red; ; 2 blue")
+	assert.Error(t, err)
+}
+
+// randomGameSets generates games with unique IDs in any order, some without
+// any draw, each draw holding a few distinct colors.
+func randomGameSets(random *rand.Rand) GameSetsInput {
+	colors := []CubeColor{"red", "green", "blue", "yellow", "light blue"}
+	gameSets := GameSetsInput{}
+
+	for _, id := range random.Perm(random.Intn(20)) {
+		game := Game{ID: id + 1, GameSet: GameSet{}}
+
+		for i := 0; i < random.Intn(5); i++ {
+			sample := CubeSample{}
+			for _, color := range colors[:1+random.Intn(len(colors))] {
+				sample[color] = random.Intn(25)
+			}
+			game.GameSet = append(game.GameSet, sample)
+		}
+
+		gameSets = append(gameSets, game)
+	}
+
+	return gameSets
+}
+
+func TestRoundTrip(t *testing.T) {
+	random := rand.New(rand.NewSource(2))
+
+	for i := 0; i < 200; i++ {
+		gameSets := randomGameSets(random)
+
+		// Text
+		text, err := gameSets.MarshalText()
+		assert.NoError(t, err)
+		for j := 0; j < 5; j++ {
+			again, _ := gameSets.MarshalText()
+			assert.Equal(t, string(text), string(again))
+		}
+
+		parsed := GameSetsInput{}
+		assert.NoError(t, parsed.UnmarshalText(text))
+		assert.Equal(t, gameSets, parsed)
+
+		lines := []string{}
+		if len(text) > 0 {
+			lines = strings.Split(string(text), "\n")
+		}
+		converted, err := ConvertInput(lines)
+		assert.NoError(t, err)
+		assert.Equal(t, gameSets, converted)
+
+		// JSON
+		data, err := json.Marshal(gameSets)
+		assert.NoError(t, err)
+		for j := 0; j < 5; j++ {
+			again, _ := json.Marshal(gameSets)
+			assert.Equal(t, string(data), string(again))
+		}
+
+		decoded := GameSetsInput{}
+		assert.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, gameSets, decoded)
+	}
+}
@@ -0,0 +1,244 @@
+package day02
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+/*
+	The Elf grabs a handful of cubes from the bag for each draw and puts them
+	back before the next one. A draw is therefore a sample without replacement
+	from the full bag, and the draws of a game are independent: the
+	probability of seeing k red and l blue cubes in a handful of k + l cubes
+	from a bag of n red and m blue cubes is C(n, k) * C(m, l) / C(n + m, k + l).
+*/
+
+// maxCandidateBags bounds the number of bags an estimate goes through.
+const maxCandidateBags = 1_000_000
+
+// tieTolerance is how much larger a log-likelihood must be to beat another.
+const tieTolerance = 1e-9
+
+// BagEstimate is the most likely bag within the searched candidates.
+type BagEstimate struct {
+	Bag           Bag
+	LogLikelihood float64
+}
+
+// LogLikelihood is the natural logarithm of the probability of seeing the
+// draws of the game from bag, -Inf if the bag can't produce them.
+func (gameSet GameSet) LogLikelihood(bag Bag) (float64, error) {
+	if err := bag.CheckColors(gameSet); err != nil {
+		return 0, err
+	}
+
+	return logLikelihood(gameSet, bag), nil
+}
+
+func (gameSet GameSet) Likelihood(bag Bag) (float64, error) {
+	logLikelihood, err := gameSet.LogLikelihood(bag)
+	if err != nil {
+		return 0, err
+	}
+
+	return math.Exp(logLikelihood), nil
+}
+
+// LogLikelihood is the log-likelihood of all the games being played
+// with the same bag.
+func (gameSets GameSetsInput) LogLikelihood(bag Bag) (float64, error) {
+	total := 0.0
+
+	for _, game := range gameSets {
+		logLikelihood, err := game.LogLikelihood(bag)
+		if err != nil {
+			return 0, fmt.Errorf("game %d: %w", game.ID, err)
+		}
+
+		total += logLikelihood
+	}
+
+	return total, nil
+}
+
+// EstimateBag returns the maximum-likelihood bag of the game among the bags
+// holding at most search[color] cubes of each color of search.
+// Ties go to the bag with the fewest cubes of the first colors in
+// alphabetical order.
+func (gameSet GameSet) EstimateBag(search Bag) (BagEstimate, error) {
+	if err := search.CheckColors(gameSet); err != nil {
+		return BagEstimate{}, err
+	}
+
+	estimate := BagEstimate{LogLikelihood: math.Inf(-1)}
+
+	err := forEachCandidateBag(gameSet, search, func(bag Bag) {
+		// Bags in the same proportions can tie up to rounding errors
+		if logLikelihood := logLikelihood(gameSet, bag); logLikelihood > estimate.LogLikelihood+tieTolerance {
+			estimate = BagEstimate{Bag: bag, LogLikelihood: logLikelihood}
+		}
+	})
+
+	return estimate, err
+}
+
+// EstimateBag returns the maximum-likelihood bag assuming all the games
+// were played with the same bag.
+func (gameSets GameSetsInput) EstimateBag(search Bag) (BagEstimate, error) {
+	allDraws := GameSet{}
+
+	for _, game := range gameSets {
+		if err := search.CheckColors(game.GameSet); err != nil {
+			return BagEstimate{}, fmt.Errorf("game %d: %w", game.ID, err)
+		}
+
+		allDraws = append(allDraws, game.GameSet...)
+	}
+
+	return allDraws.EstimateBag(search)
+}
+
+// PossibleProbability is the probability that the game was played with a
+// bag holding no more cubes of each color than bag, so that any draw it
+// could have produced would be possible. Every candidate bag of search is
+// assumed to be equally likely before looking at the draws.
+func (gameSet GameSet) PossibleProbability(bag Bag, search Bag) (float64, error) {
+	if err := bag.CheckColors(gameSet); err != nil {
+		return 0, err
+	}
+	if err := search.CheckColors(gameSet); err != nil {
+		return 0, err
+	}
+
+	// Log-likelihoods are summed relative to the largest one seen so far
+	// so that small likelihoods don't underflow to 0.
+	maxLogLikelihood := math.Inf(-1)
+	total, possible := 0.0, 0.0
+
+	err := forEachCandidateBag(gameSet, search, func(candidate Bag) {
+		logLikelihood := logLikelihood(gameSet, candidate)
+		if math.IsInf(logLikelihood, -1) {
+			return
+		}
+
+		if logLikelihood > maxLogLikelihood {
+			scale := math.Exp(maxLogLikelihood - logLikelihood)
+			total, possible = total*scale, possible*scale
+			maxLogLikelihood = logLikelihood
+		}
+
+		weight := math.Exp(logLikelihood - maxLogLikelihood)
+		total += weight
+
+		for color, count := range candidate {
+			if count > bag[color] {
+				return
+			}
+		}
+		possible += weight
+	})
+	if err != nil {
+		return 0, err
+	}
+	if total == 0 {
+		return 0, errors.New("no candidate bag can produce the draws")
+	}
+
+	return possible / total, nil
+}
+
+// forEachCandidateBag calls visit with every bag of the colors of search
+// holding between the minimum game set and search[color] cubes of each color,
+// fewer bags being unable to produce the draws. The bag passed to visit
+// is a copy.
+func forEachCandidateBag(gameSet GameSet, search Bag, visit func(bag Bag)) error {
+	if err := search.Validate(); err != nil {
+		return err
+	}
+
+	colors := make([]CubeColor, 0, len(search))
+	for color := range search {
+		colors = append(colors, color)
+	}
+	slices.Sort(colors)
+
+	minimumGameSet := gameSet.ComputeMinimumGameSet()
+
+	candidates := 1
+	for _, color := range colors {
+		if minimumGameSet[color] > search[color] {
+			return fmt.Errorf("no candidate bag holds the %d %s cubes drawn", minimumGameSet[color], color)
+		}
+
+		candidates *= search[color] - minimumGameSet[color] + 1
+		if candidates > maxCandidateBags {
+			return errors.New("too many candidate bags to search")
+		}
+	}
+
+	counts := make([]int, len(colors))
+	for i, color := range colors {
+		counts[i] = minimumGameSet[color]
+	}
+
+	for {
+		bag := Bag{}
+		for i, color := range colors {
+			bag[color] = counts[i]
+		}
+		visit(bag)
+
+		// Go to the next bag, the last color changing fastest
+		i := len(colors) - 1
+		for ; i >= 0; i-- {
+			if counts[i] < search[colors[i]] {
+				counts[i]++
+				break
+			}
+			counts[i] = minimumGameSet[colors[i]]
+		}
+		if i < 0 {
+			return nil
+		}
+	}
+}
+
+func logLikelihood(gameSet GameSet, bag Bag) float64 {
+	total := 0
+	for _, count := range bag {
+		total += count
+	}
+
+	result := 0.0
+
+	for _, sample := range gameSet {
+		handful := 0
+		for color, count := range sample {
+			if count > bag[color] {
+				return math.Inf(-1)
+			}
+
+			result += logChoose(bag[color], count)
+			handful += count
+		}
+
+		result -= logChoose(total, handful)
+	}
+
+	return result
+}
+
+// logChoose is the natural logarithm of the binomial coefficient C(n, k).
+func logChoose(n, k int) float64 {
+	if k < 0 || k > n {
+		return math.Inf(-1)
+	}
+
+	nFactorial, _ := math.Lgamma(float64(n + 1))
+	kFactorial, _ := math.Lgamma(float64(k + 1))
+	rest, _ := math.Lgamma(float64(n - k + 1))
+
+	return nFactorial - kFactorial - rest
+}
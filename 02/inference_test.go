@@ -0,0 +1,99 @@
+package day02
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLikelihood(t *testing.T) {
+	type test struct {
+		input GameSet
+		bag   Bag
+		want  float64
+	}
+
+	tests := []test{
+		// C(2, 1) * C(2, 1) / C(4, 2)
+		{GameSet{{"red": 1, "blue": 1}}, Bag{"red": 2, "blue": 2}, 2.0 / 3},
+		// Then C(2, 2) / C(4, 2) for the second draw
+		{GameSet{{"red": 1, "blue": 1}, {"red": 2}}, Bag{"red": 2, "blue": 2}, 1.0 / 9},
+		{GameSet{{"red": 3}}, Bag{"red": 2, "blue": 2}, 0},
+		{GameSet{{"red": 3}}, Bag{"red": 3}, 1},
+		// Colors of the bag absent from a draw weren't picked
+		{GameSet{{"red": 1}}, Bag{"red": 1, "green": 1, "blue": 2}, 0.25},
+	}
+
+	for _, tc := range tests {
+		got, err := tc.input.Likelihood(tc.bag)
+
+		assert.NoError(t, err)
+		assert.InDelta(t, tc.want, got, 1e-9)
+	}
+
+	_, err := GameSet{{"yellow": 1}}.Likelihood(DefaultBag)
+	assert.EqualError(t, err, `unknown color "yellow"`)
+}
+
+func TestEstimateBag(t *testing.T) {
+	search := Bag{"red": 10, "blue": 10}
+
+	// A single draw is certain from the smallest bag able to produce it
+	estimate, err := GameSet{{"red": 3, "blue": 1}}.EstimateBag(search)
+	assert.NoError(t, err)
+	assert.Equal(t, Bag{"red": 3, "blue": 1}, estimate.Bag)
+	assert.InDelta(t, 0, estimate.LogLikelihood, 1e-9)
+
+	// Drawing a single red cube many times suggests many red cubes
+	// among the blue ones
+	gameSet := GameSet{{"blue": 1}, {"red": 1}, {"red": 1}, {"red": 1}, {"red": 1}}
+	estimate, err = gameSet.EstimateBag(search)
+	assert.NoError(t, err)
+	assert.Equal(t, Bag{"red": 4, "blue": 1}, estimate.Bag)
+	assert.InDelta(t, math.Log(0.2*math.Pow(0.8, 4)), estimate.LogLikelihood, 1e-9)
+
+	_, err = gameSet.EstimateBag(Bag{"red": 10, "blue": 0})
+	assert.EqualError(t, err, "no candidate bag holds the 1 blue cubes drawn")
+
+	_, err = gameSet.EstimateBag(Bag{"red": 1000, "blue": 1000, "green": 1000})
+	assert.EqualError(t, err, "too many candidate bags to search")
+}
+
+func TestEstimateBagAcrossGames(t *testing.T) {
+	gameSets := GameSetsInput{
+		{ID: 1, GameSet: GameSet{{"blue": 1}, {"red": 1}}},
+		{ID: 2, GameSet: GameSet{{"red": 1}, {"red": 1}, {"red": 1}}},
+	}
+	search := Bag{"red": 10, "blue": 10}
+
+	estimate, err := gameSets.EstimateBag(search)
+	assert.NoError(t, err)
+	assert.Equal(t, Bag{"red": 4, "blue": 1}, estimate.Bag)
+
+	logLikelihood, err := gameSets.LogLikelihood(estimate.Bag)
+	assert.NoError(t, err)
+	assert.InDelta(t, estimate.LogLikelihood, logLikelihood, 1e-9)
+
+	// Each game on its own is best explained by a smaller bag
+	estimate, err = gameSets[0].EstimateBag(search)
+	assert.NoError(t, err)
+	assert.Equal(t, Bag{"red": 1, "blue": 1}, estimate.Bag)
+}
+
+func TestPossibleProbability(t *testing.T) {
+	// Any bag of 1 to 3 red cubes gives the same draws
+	got, err := GameSet{{"red": 1}}.PossibleProbability(Bag{"red": 2}, Bag{"red": 3})
+	assert.NoError(t, err)
+	assert.InDelta(t, 2.0/3, got, 1e-9)
+
+	// Always drawing the only blue cube makes a big bag unlikely
+	gameSet := GameSet{{"blue": 1}, {"blue": 1}, {"blue": 1}, {"blue": 1}}
+	got, err = gameSet.PossibleProbability(Bag{"red": 0, "blue": 1}, Bag{"red": 2, "blue": 1})
+	assert.NoError(t, err)
+	// Likelihoods 1, (1/2)^4 and (1/3)^4 for 0, 1 and 2 red cubes
+	assert.InDelta(t, 1/(1+1.0/16+1.0/81), got, 1e-9)
+
+	_, err = gameSet.PossibleProbability(Bag{"red": 0}, Bag{"red": 2, "blue": 1})
+	assert.EqualError(t, err, `unknown color "blue"`)
+}
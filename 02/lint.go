@@ -0,0 +1,56 @@
+package day02
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+)
+
+var (
+	gameLineRegex      = regexp.MustCompile(`^Game (\d+):(.*)$`)
+	colorAndCountRegex = regexp.MustCompile(`^(\d+) ([a-z]+)$`)
+)
+
+func LintInput(input []string) []utils.LintProblem {
+	problems := []utils.LintProblem{}
+
+	if len(input) == 0 {
+		return append(problems, utils.Lintf(0, "input is empty"))
+	}
+
+	// seenOnLine maps game IDs to the line they were first seen on
+	seenOnLine := map[int]int{}
+
+	for i, line := range input {
+		matches := gameLineRegex.FindStringSubmatch(line)
+		if matches == nil {
+			problems = append(problems, utils.Lintf(i+1, "expected \"Game <id>: <draws>\", got %q", line))
+			continue
+		}
+
+		id, _ := strconv.Atoi(matches[1])
+		if firstLine, ok := seenOnLine[id]; ok {
+			problems = append(problems, utils.Lintf(i+1, "duplicate game ID %d (first seen on line %d)", id, firstLine))
+		} else {
+			seenOnLine[id] = i + 1
+		}
+
+		// A game without draws is fine, like ParseGame reads it
+		if strings.TrimSpace(matches[2]) == "" {
+			continue
+		}
+
+		for _, draw := range strings.Split(matches[2], ";") {
+			for _, colorAndCount := range strings.Split(draw, ",") {
+				colorAndCount = strings.TrimSpace(colorAndCount)
+				if !colorAndCountRegex.MatchString(colorAndCount) {
+					problems = append(problems, utils.Lintf(i+1, "expected \"<count> <color>\", got %q", colorAndCount))
+				}
+			}
+		}
+	}
+
+	return problems
+}
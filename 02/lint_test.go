@@ -0,0 +1,27 @@
+package day02
+
+import (
+	"testing"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintInput(t *testing.T) {
+	input := []string{
+		"Game 1: 3 blue, 4 red; 1 red, 2 green, 6 blue; 2 green",
+		"Game 3: 1 blue, 2 green",
+		"Game 3 1 blue",
+		"Game 4: 1 blue, green",
+		"Game 3: 2 red",
+		"Game 5: ",
+	}
+
+	want := []utils.LintProblem{
+		{Line: 3, Message: "expected \"Game <id>: <draws>\", got \"Game 3 1 blue\""},
+		{Line: 4, Message: "expected \"<count> <color>\", got \"green\""},
+		{Line: 5, Message: "duplicate game ID 3 (first seen on line 2)"},
+	}
+
+	assert.Equal(t, want, LintInput(input))
+}
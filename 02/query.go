@@ -0,0 +1,452 @@
+package day02
+
+import (
+	"fmt"
+	"strconv"
+)
+
+/*
+	A query selects games with comparisons combined by "and", "or" and "not",
+	for example:
+
+		max(red) > 10 and not any(blue >= 3)
+		any(red > 10 and blue < 3) or id == 42
+
+	Game-level values are the aggregates max(<color>), min(<color>) and
+	sum(<color>) over the draws of the game, "draws" (the number of draws)
+	and "id". Colors absent from a draw count as 0.
+
+	any(...) and all(...) evaluate their condition against each draw,
+	where a bare color is its count in that draw.
+*/
+
+// Query is a parsed query, to be matched against games.
+type Query struct {
+	source string
+	root   queryCondition
+}
+
+// ParseQuery parses source, reporting the offset of the first error.
+func ParseQuery(source string) (*Query, error) {
+	tokens, err := tokenizeQuery(source)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := queryParser{tokens: tokens}
+
+	root, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if token := parser.peek(); token.kind != queryEnd {
+		return nil, parser.unexpected(token, "\"and\", \"or\" or end of query")
+	}
+
+	return &Query{source: source, root: root}, nil
+}
+
+func (query *Query) String() string {
+	return query.source
+}
+
+func (query *Query) Match(game Game) bool {
+	return query.root.holds(queryContext{game: game})
+}
+
+// Filter returns the games matching query, in input order.
+func (gameSets GameSetsInput) Filter(query *Query) GameSetsInput {
+	matching := GameSetsInput{}
+
+	for _, game := range gameSets {
+		if query.Match(game) {
+			matching = append(matching, game)
+		}
+	}
+
+	return matching
+}
+
+// queryContext is the game being matched, and the draw being looked at
+// inside any(...) and all(...).
+type queryContext struct {
+	game Game
+	draw CubeSample
+}
+
+type queryCondition interface {
+	holds(context queryContext) bool
+}
+
+type queryValue interface {
+	value(context queryContext) int
+}
+
+type andCondition struct{ left, right queryCondition }
+
+func (condition andCondition) holds(context queryContext) bool {
+	return condition.left.holds(context) && condition.right.holds(context)
+}
+
+type orCondition struct{ left, right queryCondition }
+
+func (condition orCondition) holds(context queryContext) bool {
+	return condition.left.holds(context) || condition.right.holds(context)
+}
+
+type notCondition struct{ operand queryCondition }
+
+func (condition notCondition) holds(context queryContext) bool {
+	return !condition.operand.holds(context)
+}
+
+// drawCondition holds when its condition holds for any draw of the game,
+// or for all of them.
+type drawCondition struct {
+	all       bool
+	condition queryCondition
+}
+
+func (condition drawCondition) holds(context queryContext) bool {
+	for _, draw := range context.game.GameSet {
+		context.draw = draw
+		if condition.condition.holds(context) != condition.all {
+			return !condition.all
+		}
+	}
+
+	return condition.all
+}
+
+type comparison struct {
+	operator    string
+	left, right queryValue
+}
+
+func (condition comparison) holds(context queryContext) bool {
+	left, right := condition.left.value(context), condition.right.value(context)
+
+	switch condition.operator {
+	case "<":
+		return left < right
+	case "<=":
+		return left <= right
+	case ">":
+		return left > right
+	case ">=":
+		return left >= right
+	case "==":
+		return left == right
+	default:
+		return left != right
+	}
+}
+
+type constantValue int
+
+func (constant constantValue) value(context queryContext) int {
+	return int(constant)
+}
+
+// drawColorValue is the count of a color in the current draw.
+type drawColorValue CubeColor
+
+func (color drawColorValue) value(context queryContext) int {
+	return context.draw[CubeColor(color)]
+}
+
+type aggregateValue struct {
+	function string
+	color    CubeColor
+}
+
+func (aggregate aggregateValue) value(context queryContext) int {
+	draws := context.game.GameSet
+	if len(draws) == 0 {
+		return 0
+	}
+
+	result := draws[0][aggregate.color]
+	for _, draw := range draws[1:] {
+		count := draw[aggregate.color]
+
+		switch aggregate.function {
+		case "max":
+			result = max(result, count)
+		case "min":
+			result = min(result, count)
+		default:
+			result += count
+		}
+	}
+
+	return result
+}
+
+type drawsCountValue struct{}
+
+func (drawsCountValue) value(context queryContext) int {
+	return len(context.game.GameSet)
+}
+
+type gameIDValue struct{}
+
+func (gameIDValue) value(context queryContext) int {
+	return context.game.ID
+}
+
+type queryTokenKind int
+
+const (
+	queryEnd queryTokenKind = iota
+	queryNumber
+	queryIdentifier
+	querySymbol
+)
+
+type queryToken struct {
+	kind   queryTokenKind
+	text   string
+	offset int
+}
+
+func (token queryToken) String() string {
+	if token.kind == queryEnd {
+		return "end of query"
+	}
+
+	return strconv.Quote(token.text)
+}
+
+func tokenizeQuery(source string) ([]queryToken, error) {
+	tokens := []queryToken{}
+
+	for i := 0; i < len(source); {
+		start := i
+
+		switch c := source[i]; {
+		case c == ' ' || c == '\t':
+			i++
+			continue
+		case c >= '0' && c <= '9':
+			for i < len(source) && source[i] >= '0' && source[i] <= '9' {
+				i++
+			}
+			tokens = append(tokens, queryToken{queryNumber, source[start:i], start})
+		case c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_':
+			for i < len(source) && (source[i] >= 'a' && source[i] <= 'z' || source[i] >= 'A' && source[i] <= 'Z' || source[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, queryToken{queryIdentifier, source[start:i], start})
+		case c == '(' || c == ')':
+			i++
+			tokens = append(tokens, queryToken{querySymbol, source[start:i], start})
+		case c == '<' || c == '>' || c == '=' || c == '!':
+			i++
+			if i < len(source) && source[i] == '=' {
+				i++
+			}
+
+			operator := source[start:i]
+			if operator == "=" || operator == "!" {
+				return nil, fmt.Errorf("offset %d: unknown operator %q", start, operator)
+			}
+			tokens = append(tokens, queryToken{querySymbol, operator, start})
+		default:
+			return nil, fmt.Errorf("offset %d: unexpected character %q", start, c)
+		}
+	}
+
+	return append(tokens, queryToken{queryEnd, "", len(source)}), nil
+}
+
+type queryParser struct {
+	tokens []queryToken
+	next   int
+
+	// inDraw is set while parsing the condition of any(...) or all(...)
+	inDraw bool
+}
+
+func (parser *queryParser) peek() queryToken {
+	return parser.tokens[parser.next]
+}
+
+func (parser *queryParser) advance() queryToken {
+	token := parser.tokens[parser.next]
+	if token.kind != queryEnd {
+		parser.next++
+	}
+
+	return token
+}
+
+// accept consumes the next token if its text is text.
+func (parser *queryParser) accept(text string) bool {
+	if token := parser.peek(); token.kind != queryNumber && token.kind != queryEnd && token.text == text {
+		parser.next++
+		return true
+	}
+
+	return false
+}
+
+func (parser *queryParser) expect(text string) error {
+	if !parser.accept(text) {
+		return parser.unexpected(parser.peek(), strconv.Quote(text))
+	}
+
+	return nil
+}
+
+func (parser *queryParser) unexpected(token queryToken, expected string) error {
+	return fmt.Errorf("offset %d: expected %s, got %s", token.offset, expected, token)
+}
+
+func (parser *queryParser) parseOr() (queryCondition, error) {
+	left, err := parser.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for parser.accept("or") {
+		right, err := parser.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orCondition{left, right}
+	}
+
+	return left, nil
+}
+
+func (parser *queryParser) parseAnd() (queryCondition, error) {
+	left, err := parser.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for parser.accept("and") {
+		right, err := parser.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andCondition{left, right}
+	}
+
+	return left, nil
+}
+
+func (parser *queryParser) parseNot() (queryCondition, error) {
+	if parser.accept("not") {
+		operand, err := parser.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		return notCondition{operand}, nil
+	}
+
+	return parser.parsePrimary()
+}
+
+func (parser *queryParser) parsePrimary() (queryCondition, error) {
+	token := parser.peek()
+
+	if parser.accept("(") {
+		condition, err := parser.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		return condition, parser.expect(")")
+	}
+
+	if token.text == "any" || token.text == "all" {
+		if parser.inDraw {
+			return nil, fmt.Errorf("offset %d: %s(...) can't be nested", token.offset, token.text)
+		}
+		parser.advance()
+
+		if err := parser.expect("("); err != nil {
+			return nil, err
+		}
+
+		parser.inDraw = true
+		condition, err := parser.parseOr()
+		parser.inDraw = false
+		if err != nil {
+			return nil, err
+		}
+
+		return drawCondition{all: token.text == "all", condition: condition}, parser.expect(")")
+	}
+
+	return parser.parseComparison()
+}
+
+func (parser *queryParser) parseComparison() (queryCondition, error) {
+	left, err := parser.parseValue()
+	if err != nil {
+		return nil, err
+	}
+
+	operator := parser.peek()
+	switch operator.text {
+	case "<", "<=", ">", ">=", "==", "!=":
+		parser.advance()
+	default:
+		return nil, parser.unexpected(operator, "a comparison operator")
+	}
+
+	right, err := parser.parseValue()
+	if err != nil {
+		return nil, err
+	}
+
+	return comparison{operator: operator.text, left: left, right: right}, nil
+}
+
+func (parser *queryParser) parseValue() (queryValue, error) {
+	token := parser.advance()
+
+	switch token.kind {
+	case queryNumber:
+		number, err := strconv.Atoi(token.text)
+		if err != nil {
+			return nil, fmt.Errorf("offset %d: %w", token.offset, err)
+		}
+
+		return constantValue(number), nil
+	case queryIdentifier:
+	default:
+		return nil, parser.unexpected(token, "a number, a color or an aggregate")
+	}
+
+	switch token.text {
+	case "and", "or", "not", "any", "all":
+		return nil, parser.unexpected(token, "a number, a color or an aggregate")
+	case "draws":
+		return drawsCountValue{}, nil
+	case "id":
+		return gameIDValue{}, nil
+	case "max", "min", "sum":
+		if err := parser.expect("("); err != nil {
+			return nil, err
+		}
+
+		color := parser.advance()
+		if color.kind != queryIdentifier {
+			return nil, parser.unexpected(color, "a color")
+		}
+
+		return aggregateValue{function: token.text, color: CubeColor(color.text)}, parser.expect(")")
+	}
+
+	if !parser.inDraw {
+		return nil, fmt.Errorf("offset %d: color %q is only defined inside any(...) or all(...)", token.offset, token.text)
+	}
+
+	return drawColorValue(token.text), nil
+}
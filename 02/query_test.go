@@ -0,0 +1,80 @@
+package day02
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuery(t *testing.T) {
+	gameSets, err := ConvertInput([]string{
+		"Game 1: 3 blue, 4 red; 1 red, 2 green, 6 blue; 2 green",
+		"Game 2: 1 blue, 2 green; 3 green, 4 blue, 1 red; 1 green, 1 blue",
+		"Game 3: 8 green, 6 blue, 20 red; 5 blue, 4 red, 13 green; 5 green, 1 red",
+		"Game 4: 1 green, 3 red, 6 blue; 3 green, 6 red; 3 green, 15 blue, 14 red",
+		"Game 5: 6 red, 1 blue, 3 green; 2 blue, 1 red, 2 green",
+	})
+	assert.NoError(t, err)
+
+	type test struct {
+		query string
+		want  []int
+	}
+
+	tests := []test{
+		{"max(red) > 12", []int{3, 4}},
+		{"any(red > 10 and blue < 10)", []int{3}},
+		// Same colors, but not necessarily in the same draw
+		{"max(red) > 10 and min(blue) < 10", []int{3, 4}},
+		{"all(red >= 1)", []int{3, 4, 5}},
+		{"not any(red >= 1)", []int{}},
+		// Absent colors count as 0
+		{"min(red) == 0", []int{1, 2}},
+		{"sum(blue) >= 10 or id == 2", []int{2, 3, 4}},
+		{"draws == 2 or (id >= 4 and not max(green) > 3)", []int{4, 5}},
+		{"not id == 1 and not id == 2 or id == 1", []int{1, 3, 4, 5}},
+		{"any(red + 1)", nil},
+	}
+
+	for _, tc := range tests {
+		query, err := ParseQuery(tc.query)
+		if tc.want == nil {
+			assert.Error(t, err, tc.query)
+			continue
+		}
+		assert.NoError(t, err, tc.query)
+
+		got := []int{}
+		for _, game := range gameSets.Filter(query) {
+			got = append(got, game.ID)
+		}
+
+		assert.Equal(t, tc.want, got, tc.query)
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	type test struct {
+		query string
+		want  string
+	}
+
+	tests := []test{
+		{"", "offset 0: expected a number, a color or an aggregate, got end of query"},
+		{"red > 3", `offset 0: color "red" is only defined inside any(...) or all(...)`},
+		{"any(all(red > 3))", "offset 4: all(...) can't be nested"},
+		{"max(red) = 3", `offset 9: unknown operator "="`},
+		{"max(red) > 3 blue", `offset 13: expected "and", "or" or end of query, got "blue"`},
+		{"max(red > 3", `offset 8: expected ")", got ">"`},
+		{"max(red) > 3 and", "offset 16: expected a number, a color or an aggregate, got end of query"},
+		{"any(red > 3", `offset 11: expected ")", got end of query`},
+		{"max(red) > 3 & 4", `offset 13: unexpected character '&'`},
+		{"max(red)", "offset 8: expected a comparison operator, got end of query"},
+	}
+
+	for _, tc := range tests {
+		_, err := ParseQuery(tc.query)
+
+		assert.EqualError(t, err, tc.want, tc.query)
+	}
+}
@@ -0,0 +1,90 @@
+package day02
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+)
+
+func (gameSets GameSetsInput) REPL(bag Bag) utils.REPL {
+	return utils.REPL{
+		Prompt: "day02> ",
+		Commands: map[string]utils.REPLCommand{
+			"game": {
+				Usage: "game <id>",
+				Run: func(args []string) (string, error) {
+					gameSet, err := gameSets.lookupGame(args)
+					if err != nil {
+						return "", err
+					}
+
+					return gameSet.String(), nil
+				},
+			},
+			"minimum": {
+				Usage: "minimum <id>",
+				Run: func(args []string) (string, error) {
+					gameSet, err := gameSets.lookupGame(args)
+					if err != nil {
+						return "", err
+					}
+
+					return formatCubeCounts(gameSet.ComputeMinimumGameSet()), nil
+				},
+			},
+			"possible": {
+				Usage: "possible <id>",
+				Run: func(args []string) (string, error) {
+					gameSet, err := gameSets.lookupGame(args)
+					if err != nil {
+						return "", err
+					}
+
+					possible, err := gameSet.IsPossibleWithBag(bag)
+					if err != nil {
+						return "", err
+					}
+
+					return fmt.Sprintf("%t", possible), nil
+				},
+			},
+			"filter": {
+				Usage: "filter <query>",
+				Run: func(args []string) (string, error) {
+					query, err := ParseQuery(strings.Join(args, " "))
+					if err != nil {
+						return "", err
+					}
+
+					ids := []string{}
+					for _, game := range gameSets.Filter(query) {
+						ids = append(ids, fmt.Sprintf("%d", game.ID))
+					}
+
+					return strings.Join(ids, " "), nil
+				},
+			},
+			"count": {
+				Usage: "count",
+				Run: func(args []string) (string, error) {
+					return fmt.Sprintf("%d", len(gameSets)), nil
+				},
+			},
+		},
+	}
+}
+
+func (gameSets GameSetsInput) lookupGame(args []string) (GameSet, error) {
+	values, err := utils.IntArgs(args, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	game, ok := gameSets.Lookup(values[0])
+	if !ok {
+		return nil, fmt.Errorf("no game %d", values[0])
+	}
+
+	return game.GameSet, nil
+}
@@ -1,37 +1,120 @@
-package main
+package day03
 
 import (
+	"cmp"
+	"embed"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"os"
 	"slices"
-	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/angristan/advent-of-code-2023/utils"
 )
 
-func main() {
-	input := utils.ParseInput("input.txt")
+//go:embed input.txt example*.txt
+var inputs embed.FS
+
+// Run prints the sum of the part numbers and of the gear ratios, or the
+// report subcommand's analysis of the schematic, as a table or as JSON.
+func Run(args []string) error {
+	config := DefaultConfig()
+
+	return utils.DayRunner{
+		Day:    "03",
+		Inputs: inputs,
+		Config: &config,
+		Lint: func(input []string) []utils.LintProblem {
+			return LintInput(input, config.Parse)
+		},
+		Solve: func(input []string) error {
+			engineSchematic, err := ConvertInputToEngineSchematicWithOptions(input, config.Parse)
+			if err != nil {
+				return err
+			}
+
+			part1Sum := engineSchematic.ComputeSumOfPartNumbers()
+			fmt.Printf("Part 1: %d\n", part1Sum)
+
+			part2Sum := engineSchematic.SumOfAllGearRatiosWithRule(config.Gear)
+			fmt.Printf("Part 2: %d\n", part2Sum)
+
+			return nil
+		},
+		Subcommands: map[string]utils.Subcommand{
+			"report": {
+				Usage: "report [json]",
+				Run: func(input []string, options *utils.Options) error {
+					engineSchematic, err := ConvertInputToEngineSchematicWithOptions(input, config.Parse)
+					if err != nil {
+						return err
+					}
+
+					report := engineSchematic.Report(config.Gear)
 
-	engineSchematic := ConvertInputToEngineSchematic(input)
-	part1Sum := engineSchematic.ComputeSumOfPartNumbers()
-	fmt.Printf("Part 1: %d\n", part1Sum)
+					if options.Arg(1) == "json" {
+						encoder := json.NewEncoder(os.Stdout)
+						encoder.SetIndent("", "  ")
+						return encoder.Encode(report)
+					}
 
-	part2Sum := engineSchematic.SumOfAllGearRatios()
-	fmt.Printf("Part 2: %d\n", part2Sum)
+					return report.WriteTable(os.Stdout)
+				},
+			},
+		},
+	}.Run(args)
 }
 
+// Number is a horizontal run of Length digits starting at Start. A number
+// wrapping across lines goes on with Wraps[i] digits from the first column
+// of the i-th next line. Numbers are identified by their position in
+// EngineSchematic.Numbers, which is their reading order.
 type Number struct {
-	Value             string
-	DigitsCoordinates []Coordinates
+	Value  int         `json:"value"`
+	Start  Coordinates `json:"start"`
+	Length int         `json:"length"`
+	Wraps  []int       `json:"wraps,omitempty"`
+}
+
+// segmentCount is the count of lines the digits of the number are on.
+func (nb Number) segmentCount() int {
+	return 1 + len(nb.Wraps)
+}
+
+// segment returns where the digits of the number on its i-th line start,
+// and how many there are.
+func (nb Number) segment(i int) (Coordinates, int) {
+	if i == 0 {
+		return nb.Start, nb.Length
+	}
+
+	return Coordinates{X: 0, Y: nb.Start.Y + i}, nb.Wraps[i-1]
 }
 
 type Symbol struct {
-	Value       string
-	Coordinates Coordinates
+	Value       string      `json:"value"`
+	Coordinates Coordinates `json:"coordinates"`
 }
 
 type Coordinates struct {
-	X int
-	Y int
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+func (c Coordinates) String() string {
+	return fmt.Sprintf("(%d, %d)", c.X, c.Y)
+}
+
+// Compare orders coordinates in reading order, row by row.
+func (c Coordinates) Compare(other Coordinates) int {
+	if c.Y != other.Y {
+		return cmp.Compare(c.Y, other.Y)
+	}
+
+	return cmp.Compare(c.X, other.X)
 }
 
 type EngineSchematic struct {
@@ -46,76 +129,172 @@ but apparently any number adjacent to a symbol, even diagonally, is a "part numb
 and should be included in your sum. (Periods (.) do not count as a symbol.)
 */
 
+// ParseOptions tell which characters are blanks rather than symbols, and
+// whether a minus sign right before a number is part of it. A minus sign
+// right after a digit stays a symbol, so "12-3" is 12 and 3.
+// With WrapNumbers, a number running to the end of its line goes on at the
+// start of the next line when that line starts with a digit.
+type ParseOptions struct {
+	Blanks        string `json:"blanks"`
+	SignedNumbers bool   `json:"signedNumbers"`
+	WrapNumbers   bool   `json:"wrapNumbers"`
+}
+
+var DefaultParseOptions = ParseOptions{
+	Blanks: ".",
+}
+
+func (options ParseOptions) Validate() error {
+	for _, blank := range options.Blanks {
+		if utils.IsRuneADigit(blank) {
+			return fmt.Errorf("digit %q can't be a blank", blank)
+		}
+
+		if blank == '-' && options.SignedNumbers {
+			return errors.New("'-' can't be a blank with signed numbers")
+		}
+	}
+
+	return nil
+}
+
+func (options ParseOptions) isBlank(char rune) bool {
+	return strings.ContainsRune(options.Blanks, char)
+}
+
+func (options ParseOptions) isSign(chars []rune, x int) bool {
+	return options.SignedNumbers && chars[x] == '-' &&
+		x+1 < len(chars) && utils.IsRuneADigit(chars[x+1]) &&
+		(x == 0 || !utils.IsRuneADigit(chars[x-1]))
+}
+
+// NumberOverflowError reports a number of the schematic too large for an
+// int. Line and Column are 1-indexed, Column counting runes.
+type NumberOverflowError struct {
+	Line   int
+	Column int
+}
+
+func (err *NumberOverflowError) Error() string {
+	return fmt.Sprintf("line %d: number at column %d overflows int", err.Line, err.Column)
+}
+
+// ConvertInputToEngineSchematic panics on numbers that don't fit in an int.
 func ConvertInputToEngineSchematic(input []string) EngineSchematic {
+	engineSchematic, err := ConvertInputToEngineSchematicWithOptions(input, DefaultParseOptions)
+	if err != nil {
+		panic(err)
+	}
+
+	return engineSchematic
+}
+
+// ConvertInputToEngineSchematicWithOptions counts columns in runes,
+// so that coordinates are the same whatever the encoding of the symbols.
+// It fails with a *NumberOverflowError on numbers that don't fit in an int.
+func ConvertInputToEngineSchematicWithOptions(input []string, options ParseOptions) (EngineSchematic, error) {
 	numbers := make([]Number, 0)
+	symbols := make([]Symbol, 0)
+
+	// The number being read, which may have started on a previous line
+	tempNumber := Number{}
+	negative := false
+
+	endNumber := func() {
+		if negative {
+			tempNumber.Value = -tempNumber.Value
+		}
+		numbers = append(numbers, tempNumber)
+		tempNumber = Number{}
+		negative = false
+	}
 
 	for y, line := range input {
-		tempNumber := Number{}
-		for x, char := range line {
-			if utils.IsRuneADigit(char) {
-				if len(tempNumber.DigitsCoordinates) == 0 { // New number
-					tempNumber.Value = fmt.Sprintf("%d", int(char-'0'))
-					tempNumber.DigitsCoordinates = append(tempNumber.DigitsCoordinates, Coordinates{x, y})
-				} else { // existing number
-					tempNumber.Value += fmt.Sprintf("%d", int(char-'0'))
-					tempNumber.DigitsCoordinates = append(tempNumber.DigitsCoordinates, Coordinates{x, y})
+		chars := []rune(line)
+
+		for x, char := range chars {
+			if options.isSign(chars, x) {
+				tempNumber.Start = Coordinates{x, y}
+				tempNumber.Length = 1
+				negative = true
+			} else if utils.IsRuneADigit(char) {
+				if tempNumber.Length == 0 { // New number
+					tempNumber.Start = Coordinates{x, y}
+				}
+				digit := int(char - '0')
+				if tempNumber.Value > (math.MaxInt-digit)/10 {
+					return EngineSchematic{}, &NumberOverflowError{Line: tempNumber.Start.Y + 1, Column: tempNumber.Start.X + 1}
+				}
+				tempNumber.Value = tempNumber.Value*10 + digit
+				if len(tempNumber.Wraps) > 0 {
+					tempNumber.Wraps[len(tempNumber.Wraps)-1]++
+				} else {
+					tempNumber.Length++
 				}
 			} else {
-				if len(tempNumber.DigitsCoordinates) > 0 { //end of number
-					numbers = append(numbers, tempNumber)
-					tempNumber = Number{}
+				if tempNumber.Length > 0 { //end of number
+					endNumber()
+				}
+
+				if !options.isBlank(char) {
+					symbols = append(symbols, Symbol{
+						Coordinates: Coordinates{x, y},
+						Value:       string(char),
+					})
 				}
 			}
 		}
-		if len(tempNumber.DigitsCoordinates) > 0 { //end of line
-			numbers = append(numbers, tempNumber)
-			tempNumber = Number{}
-		}
-	}
-
-	symbols := make([]Symbol, 0)
-
-	for y, line := range input {
-		for x, char := range line {
-			if !utils.IsRuneADigit(char) && char != '.' {
-				symbols = append(symbols, Symbol{
-					Coordinates: Coordinates{x, y},
-					Value:       string(char),
-				})
+		if tempNumber.Length > 0 { //end of line
+			if options.WrapNumbers && y+1 < len(input) && startsWithDigit(input[y+1]) {
+				tempNumber.Wraps = append(tempNumber.Wraps, 0)
+				continue
 			}
+
+			endNumber()
 		}
 	}
 
-	return EngineSchematic{numbers, symbols}
+	return EngineSchematic{
+		Numbers: numbers,
+		Symbols: symbols,
+	}, nil
+}
+
+func startsWithDigit(line string) bool {
+	char, _ := utf8.DecodeRuneInString(line)
+	return utils.IsRuneADigit(char)
 }
 
 func (es EngineSchematic) GetPartNumbersValues() []int {
+	index := es.coordinateIndex()
 	partNumbers := make([]int, 0)
 
-nextNumber:
 	for _, number := range es.Numbers {
-		for _, adjCoordsOfDigit := range number.GetAllAdjacentCoordinates() {
-			for _, symbol := range es.Symbols {
-				if adjCoordsOfDigit == symbol.Coordinates {
-					intValue, err := strconv.Atoi(number.Value)
-					if err != nil {
-						panic(err)
-					}
-					partNumbers = append(partNumbers, intValue)
-
-					continue nextNumber
-				}
-			}
+		if index.touchesSymbol(number) {
+			partNumbers = append(partNumbers, number.Value)
 		}
 	}
 
 	return partNumbers
 }
 
+func (nb Number) DigitsCoordinates() []Coordinates {
+	digitsCoordinates := make([]Coordinates, 0, nb.Length)
+
+	for i := 0; i < nb.segmentCount(); i++ {
+		start, length := nb.segment(i)
+		for x := start.X; x < start.X+length; x++ {
+			digitsCoordinates = append(digitsCoordinates, Coordinates{x, start.Y})
+		}
+	}
+
+	return digitsCoordinates
+}
+
 func (nb Number) GetAllAdjacentCoordinates() []Coordinates {
 	adjacentCoordinates := make([]Coordinates, 0)
 
-	for _, digits := range nb.DigitsCoordinates {
+	for _, digits := range nb.DigitsCoordinates() {
 		adjacentCoordinates = append(adjacentCoordinates, Coordinates{digits.X - 1, digits.Y - 1})
 		adjacentCoordinates = append(adjacentCoordinates, Coordinates{digits.X, digits.Y - 1})
 		adjacentCoordinates = append(adjacentCoordinates, Coordinates{digits.X + 1, digits.Y - 1})
@@ -140,63 +319,138 @@ func (es EngineSchematic) ComputeSumOfPartNumbers() int {
 	return sum
 }
 
+// Gear is a gear symbol along with the IDs and the values of the part
+// numbers adjacent to it, in reading order.
 type Gear struct {
-	Values []int
+	Coordinates Coordinates
+	NumberIDs   []int
+	Values      []int
 }
 
 /*
 A gear is any * symbol that is adjacent to exactly two part numbers.
+Its gear ratio is the result of multiplying those two numbers together.
 */
-func (es EngineSchematic) GetGears() []Gear {
-	asteriskSymbolToNumbers := make(map[Coordinates][]Number)
 
-	for _, symbol := range es.Symbols {
-		if symbol.Value == "*" {
-			asteriskSymbolToNumbers[symbol.Coordinates] = make([]Number, 0)
+type GearAggregation string
 
-			for _, number := range es.Numbers {
-				if slices.Contains(number.GetAllAdjacentCoordinates(), symbol.Coordinates) {
-					asteriskSymbolToNumbers[symbol.Coordinates] = append(asteriskSymbolToNumbers[symbol.Coordinates], number)
-				}
-			}
+const (
+	GearProduct GearAggregation = "product"
+	GearSum     GearAggregation = "sum"
+)
+
+// GearRule describes which symbols are gears, how many part numbers
+// they must be adjacent to, and how their ratio is computed from the values
+// of those numbers. An exact count of parts sets both MinParts and MaxParts.
+type GearRule struct {
+	Symbols     []string        `json:"symbols"`
+	MinParts    int             `json:"minParts"`
+	MaxParts    int             `json:"maxParts"`
+	Aggregation GearAggregation `json:"aggregation"`
+}
+
+var DefaultGearRule = GearRule{
+	Symbols:     []string{"*"},
+	MinParts:    2,
+	MaxParts:    2,
+	Aggregation: GearProduct,
+}
+
+func (rule GearRule) Validate() error {
+	if len(rule.Symbols) == 0 {
+		return errors.New("gear rule has no symbols")
+	}
+
+	for _, symbol := range rule.Symbols {
+		if utf8.RuneCountInString(symbol) != 1 || symbol == "." || utils.IsRuneADigit(rune(symbol[0])) {
+			return fmt.Errorf("gear symbol %q is not a symbol", symbol)
+		}
+	}
+
+	if rule.MinParts < 1 {
+		return fmt.Errorf("gears need at least 1 part, got minParts %d", rule.MinParts)
+	}
+
+	if rule.MaxParts < rule.MinParts {
+		return fmt.Errorf("maxParts %d is less than minParts %d", rule.MaxParts, rule.MinParts)
+	}
+
+	if rule.Aggregation != GearProduct && rule.Aggregation != GearSum {
+		return fmt.Errorf("unknown gear aggregation %q", rule.Aggregation)
+	}
+
+	return nil
+}
+
+// Ratio aggregates the values of the part numbers of gear.
+func (rule GearRule) Ratio(gear Gear) int {
+	if rule.Aggregation == GearSum {
+		sum := 0
+		for _, value := range gear.Values {
+			sum += value
 		}
+
+		return sum
 	}
 
+	return gear.GetGearRatio()
+}
+
+func (es EngineSchematic) GetGears() []Gear {
+	return es.GetGearsWithRule(DefaultGearRule)
+}
+
+func (es EngineSchematic) GetGearsWithRule(rule GearRule) []Gear {
+	index := es.coordinateIndex()
 	gears := make([]Gear, 0)
 
-	for _, numbers := range asteriskSymbolToNumbers {
-		if len(numbers) == 2 {
-			valueInt1, err := strconv.Atoi(numbers[0].Value)
-			if err != nil {
-				panic(err)
-			}
+	for _, symbol := range es.Symbols {
+		if !slices.Contains(rule.Symbols, symbol.Value) {
+			continue
+		}
 
-			valueInt2, err := strconv.Atoi(numbers[1].Value)
-			if err != nil {
-				panic(err)
-			}
+		ids := index.numbersAround(symbol.Coordinates)
+		if len(ids) < rule.MinParts || len(ids) > rule.MaxParts {
+			continue
+		}
 
-			gears = append(gears, Gear{Values: []int{valueInt1, valueInt2}})
+		gear := Gear{Coordinates: symbol.Coordinates, NumberIDs: ids}
+		for _, id := range ids {
+			gear.Values = append(gear.Values, es.Numbers[id].Value)
 		}
+
+		gears = append(gears, gear)
 	}
 
+	// Symbols of hand-made schematics may not be in reading order
+	slices.SortStableFunc(gears, func(a, b Gear) int {
+		return a.Coordinates.Compare(b.Coordinates)
+	})
+
 	return gears
 }
 
-/*
-Its gear ratio is the result of multiplying those two numbers together.
-*/
+// GetGearRatio multiplies the values of the part numbers of the gear.
 func (g Gear) GetGearRatio() int {
-	return g.Values[0] * g.Values[1]
+	ratio := 1
+	for _, value := range g.Values {
+		ratio *= value
+	}
+
+	return ratio
 }
 
 func (es EngineSchematic) SumOfAllGearRatios() int {
-	gears := es.GetGears()
+	return es.SumOfAllGearRatiosWithRule(DefaultGearRule)
+}
+
+func (es EngineSchematic) SumOfAllGearRatiosWithRule(rule GearRule) int {
+	gears := es.GetGearsWithRule(rule)
 
 	sum := 0
 
 	for _, gear := range gears {
-		sum += gear.GetGearRatio()
+		sum += rule.Ratio(gear)
 	}
 
 	return sum
@@ -1,4 +1,4 @@
-package main
+package day03
 
 import (
 	"testing"
@@ -28,16 +28,16 @@ func TestComputeEngineSchematic(t *testing.T) {
 			},
 			want: EngineSchematic{
 				Numbers: []Number{
-					{Value: "467", DigitsCoordinates: []Coordinates{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}}},
-					{Value: "114", DigitsCoordinates: []Coordinates{{X: 5, Y: 0}, {X: 6, Y: 0}, {X: 7, Y: 0}}},
-					{Value: "35", DigitsCoordinates: []Coordinates{{X: 2, Y: 2}, {X: 3, Y: 2}}},
-					{Value: "633", DigitsCoordinates: []Coordinates{{X: 6, Y: 2}, {X: 7, Y: 2}, {X: 8, Y: 2}}},
-					{Value: "617", DigitsCoordinates: []Coordinates{{X: 0, Y: 4}, {X: 1, Y: 4}, {X: 2, Y: 4}}},
-					{Value: "58", DigitsCoordinates: []Coordinates{{X: 7, Y: 5}, {X: 8, Y: 5}}},
-					{Value: "592", DigitsCoordinates: []Coordinates{{X: 2, Y: 6}, {X: 3, Y: 6}, {X: 4, Y: 6}}},
-					{Value: "755", DigitsCoordinates: []Coordinates{{X: 6, Y: 7}, {X: 7, Y: 7}, {X: 8, Y: 7}}},
-					{Value: "664", DigitsCoordinates: []Coordinates{{X: 1, Y: 9}, {X: 2, Y: 9}, {X: 3, Y: 9}}},
-					{Value: "598", DigitsCoordinates: []Coordinates{{X: 5, Y: 9}, {X: 6, Y: 9}, {X: 7, Y: 9}}},
+					{Value: 467, Start: Coordinates{X: 0, Y: 0}, Length: 3},
+					{Value: 114, Start: Coordinates{X: 5, Y: 0}, Length: 3},
+					{Value: 35, Start: Coordinates{X: 2, Y: 2}, Length: 2},
+					{Value: 633, Start: Coordinates{X: 6, Y: 2}, Length: 3},
+					{Value: 617, Start: Coordinates{X: 0, Y: 4}, Length: 3},
+					{Value: 58, Start: Coordinates{X: 7, Y: 5}, Length: 2},
+					{Value: 592, Start: Coordinates{X: 2, Y: 6}, Length: 3},
+					{Value: 755, Start: Coordinates{X: 6, Y: 7}, Length: 3},
+					{Value: 664, Start: Coordinates{X: 1, Y: 9}, Length: 3},
+					{Value: 598, Start: Coordinates{X: 5, Y: 9}, Length: 3},
 				},
 				Symbols: []Symbol{
 					{Coordinates: Coordinates{X: 3, Y: 1}, Value: "*"},
@@ -69,21 +69,21 @@ func TestComputeEngineSchematic(t *testing.T) {
 			},
 			want: EngineSchematic{
 				Numbers: []Number{
-					{Value: "12", DigitsCoordinates: []Coordinates{{X: 0, Y: 0}, {X: 1, Y: 0}}},
-					{Value: "34", DigitsCoordinates: []Coordinates{{X: 10, Y: 1}, {X: 11, Y: 1}}},
-					{Value: "12", DigitsCoordinates: []Coordinates{{X: 8, Y: 2}, {X: 9, Y: 2}}},
-					{Value: "78", DigitsCoordinates: []Coordinates{{X: 2, Y: 3}, {X: 3, Y: 3}}},
-					{Value: "60", DigitsCoordinates: []Coordinates{{X: 7, Y: 4}, {X: 8, Y: 4}}},
-					{Value: "78", DigitsCoordinates: []Coordinates{{X: 0, Y: 5}, {X: 1, Y: 5}}},
-					{Value: "23", DigitsCoordinates: []Coordinates{{X: 7, Y: 6}, {X: 8, Y: 6}}},
-					{Value: "90", DigitsCoordinates: []Coordinates{{X: 4, Y: 7}, {X: 5, Y: 7}}},
-					{Value: "12", DigitsCoordinates: []Coordinates{{X: 7, Y: 7}, {X: 8, Y: 7}}},
-					{Value: "2", DigitsCoordinates: []Coordinates{{X: 0, Y: 9}}},
-					{Value: "2", DigitsCoordinates: []Coordinates{{X: 2, Y: 9}}},
-					{Value: "12", DigitsCoordinates: []Coordinates{{X: 9, Y: 9}, {X: 10, Y: 9}}},
-					{Value: "1", DigitsCoordinates: []Coordinates{{X: 0, Y: 11}}},
-					{Value: "1", DigitsCoordinates: []Coordinates{{X: 2, Y: 11}}},
-					{Value: "56", DigitsCoordinates: []Coordinates{{X: 10, Y: 11}, {X: 11, Y: 11}}},
+					{Value: 12, Start: Coordinates{X: 0, Y: 0}, Length: 2},
+					{Value: 34, Start: Coordinates{X: 10, Y: 1}, Length: 2},
+					{Value: 12, Start: Coordinates{X: 8, Y: 2}, Length: 2},
+					{Value: 78, Start: Coordinates{X: 2, Y: 3}, Length: 2},
+					{Value: 60, Start: Coordinates{X: 7, Y: 4}, Length: 2},
+					{Value: 78, Start: Coordinates{X: 0, Y: 5}, Length: 2},
+					{Value: 23, Start: Coordinates{X: 7, Y: 6}, Length: 2},
+					{Value: 90, Start: Coordinates{X: 4, Y: 7}, Length: 2},
+					{Value: 12, Start: Coordinates{X: 7, Y: 7}, Length: 2},
+					{Value: 2, Start: Coordinates{X: 0, Y: 9}, Length: 1},
+					{Value: 2, Start: Coordinates{X: 2, Y: 9}, Length: 1},
+					{Value: 12, Start: Coordinates{X: 9, Y: 9}, Length: 2},
+					{Value: 1, Start: Coordinates{X: 0, Y: 11}, Length: 1},
+					{Value: 1, Start: Coordinates{X: 2, Y: 11}, Length: 1},
+					{Value: 56, Start: Coordinates{X: 10, Y: 11}, Length: 2},
 				},
 				Symbols: []Symbol{
 					{Coordinates: Coordinates{X: 9, Y: 0}, Value: "*"},
@@ -115,16 +115,16 @@ func TestGetPartNumbers(t *testing.T) {
 		{
 			input: EngineSchematic{
 				Numbers: []Number{
-					{Value: "467", DigitsCoordinates: []Coordinates{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}}},
-					{Value: "114", DigitsCoordinates: []Coordinates{{X: 5, Y: 0}, {X: 6, Y: 0}, {X: 7, Y: 0}}},
-					{Value: "35", DigitsCoordinates: []Coordinates{{X: 2, Y: 2}, {X: 3, Y: 2}}},
-					{Value: "633", DigitsCoordinates: []Coordinates{{X: 6, Y: 2}, {X: 7, Y: 2}, {X: 8, Y: 2}}},
-					{Value: "617", DigitsCoordinates: []Coordinates{{X: 0, Y: 4}, {X: 1, Y: 4}, {X: 2, Y: 4}}},
-					{Value: "58", DigitsCoordinates: []Coordinates{{X: 7, Y: 5}, {X: 8, Y: 5}}},
-					{Value: "592", DigitsCoordinates: []Coordinates{{X: 2, Y: 6}, {X: 3, Y: 6}, {X: 4, Y: 6}}},
-					{Value: "755", DigitsCoordinates: []Coordinates{{X: 6, Y: 7}, {X: 7, Y: 7}, {X: 8, Y: 7}}},
-					{Value: "664", DigitsCoordinates: []Coordinates{{X: 1, Y: 9}, {X: 2, Y: 9}, {X: 3, Y: 9}}},
-					{Value: "598", DigitsCoordinates: []Coordinates{{X: 5, Y: 9}, {X: 6, Y: 9}, {X: 7, Y: 9}}},
+					{Value: 467, Start: Coordinates{X: 0, Y: 0}, Length: 3},
+					{Value: 114, Start: Coordinates{X: 5, Y: 0}, Length: 3},
+					{Value: 35, Start: Coordinates{X: 2, Y: 2}, Length: 2},
+					{Value: 633, Start: Coordinates{X: 6, Y: 2}, Length: 3},
+					{Value: 617, Start: Coordinates{X: 0, Y: 4}, Length: 3},
+					{Value: 58, Start: Coordinates{X: 7, Y: 5}, Length: 2},
+					{Value: 592, Start: Coordinates{X: 2, Y: 6}, Length: 3},
+					{Value: 755, Start: Coordinates{X: 6, Y: 7}, Length: 3},
+					{Value: 664, Start: Coordinates{X: 1, Y: 9}, Length: 3},
+					{Value: 598, Start: Coordinates{X: 5, Y: 9}, Length: 3},
 				},
 				Symbols: []Symbol{
 					{Coordinates: Coordinates{X: 3, Y: 1}, Value: "*"},
@@ -140,21 +140,21 @@ func TestGetPartNumbers(t *testing.T) {
 		{
 			input: EngineSchematic{
 				Numbers: []Number{
-					{Value: "12", DigitsCoordinates: []Coordinates{{X: 0, Y: 0}, {X: 1, Y: 0}}},
-					{Value: "34", DigitsCoordinates: []Coordinates{{X: 10, Y: 1}, {X: 11, Y: 1}}},
-					{Value: "12", DigitsCoordinates: []Coordinates{{X: 8, Y: 2}, {X: 9, Y: 2}}},
-					{Value: "78", DigitsCoordinates: []Coordinates{{X: 2, Y: 3}, {X: 3, Y: 3}}},
-					{Value: "60", DigitsCoordinates: []Coordinates{{X: 7, Y: 4}, {X: 8, Y: 4}}},
-					{Value: "78", DigitsCoordinates: []Coordinates{{X: 0, Y: 5}, {X: 1, Y: 5}}},
-					{Value: "23", DigitsCoordinates: []Coordinates{{X: 7, Y: 6}, {X: 8, Y: 6}}},
-					{Value: "90", DigitsCoordinates: []Coordinates{{X: 4, Y: 7}, {X: 5, Y: 7}}},
-					{Value: "12", DigitsCoordinates: []Coordinates{{X: 7, Y: 7}, {X: 8, Y: 7}}},
-					{Value: "2", DigitsCoordinates: []Coordinates{{X: 0, Y: 9}}},
-					{Value: "2", DigitsCoordinates: []Coordinates{{X: 2, Y: 9}}},
-					{Value: "12", DigitsCoordinates: []Coordinates{{X: 9, Y: 9}, {X: 10, Y: 9}}},
-					{Value: "1", DigitsCoordinates: []Coordinates{{X: 0, Y: 11}}},
-					{Value: "1", DigitsCoordinates: []Coordinates{{X: 2, Y: 11}}},
-					{Value: "56", DigitsCoordinates: []Coordinates{{X: 10, Y: 11}, {X: 11, Y: 11}}},
+					{Value: 12, Start: Coordinates{X: 0, Y: 0}, Length: 2},
+					{Value: 34, Start: Coordinates{X: 10, Y: 1}, Length: 2},
+					{Value: 12, Start: Coordinates{X: 8, Y: 2}, Length: 2},
+					{Value: 78, Start: Coordinates{X: 2, Y: 3}, Length: 2},
+					{Value: 60, Start: Coordinates{X: 7, Y: 4}, Length: 2},
+					{Value: 78, Start: Coordinates{X: 0, Y: 5}, Length: 2},
+					{Value: 23, Start: Coordinates{X: 7, Y: 6}, Length: 2},
+					{Value: 90, Start: Coordinates{X: 4, Y: 7}, Length: 2},
+					{Value: 12, Start: Coordinates{X: 7, Y: 7}, Length: 2},
+					{Value: 2, Start: Coordinates{X: 0, Y: 9}, Length: 1},
+					{Value: 2, Start: Coordinates{X: 2, Y: 9}, Length: 1},
+					{Value: 12, Start: Coordinates{X: 9, Y: 9}, Length: 2},
+					{Value: 1, Start: Coordinates{X: 0, Y: 11}, Length: 1},
+					{Value: 1, Start: Coordinates{X: 2, Y: 11}, Length: 1},
+					{Value: 56, Start: Coordinates{X: 10, Y: 11}, Length: 2},
 				},
 				Symbols: []Symbol{
 					{Coordinates: Coordinates{X: 9, Y: 0}, Value: "*"},
@@ -180,16 +180,16 @@ func TestGetPartNumbers(t *testing.T) {
 func TestComputeSumOfPartNumbers(t *testing.T) {
 	engineSchematic := EngineSchematic{
 		Numbers: []Number{
-			{Value: "467", DigitsCoordinates: []Coordinates{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}}},
-			{Value: "114", DigitsCoordinates: []Coordinates{{X: 5, Y: 0}, {X: 6, Y: 0}, {X: 7, Y: 0}}},
-			{Value: "35", DigitsCoordinates: []Coordinates{{X: 2, Y: 2}, {X: 3, Y: 2}}},
-			{Value: "633", DigitsCoordinates: []Coordinates{{X: 6, Y: 2}, {X: 7, Y: 2}, {X: 8, Y: 2}}},
-			{Value: "617", DigitsCoordinates: []Coordinates{{X: 0, Y: 4}, {X: 1, Y: 4}, {X: 2, Y: 4}}},
-			{Value: "58", DigitsCoordinates: []Coordinates{{X: 7, Y: 5}, {X: 8, Y: 5}}},
-			{Value: "592", DigitsCoordinates: []Coordinates{{X: 2, Y: 6}, {X: 3, Y: 6}, {X: 4, Y: 6}}},
-			{Value: "755", DigitsCoordinates: []Coordinates{{X: 6, Y: 7}, {X: 7, Y: 7}, {X: 8, Y: 7}}},
-			{Value: "664", DigitsCoordinates: []Coordinates{{X: 1, Y: 9}, {X: 2, Y: 9}, {X: 3, Y: 9}}},
-			{Value: "598", DigitsCoordinates: []Coordinates{{X: 5, Y: 9}, {X: 6, Y: 9}, {X: 7, Y: 9}}},
+			{Value: 467, Start: Coordinates{X: 0, Y: 0}, Length: 3},
+			{Value: 114, Start: Coordinates{X: 5, Y: 0}, Length: 3},
+			{Value: 35, Start: Coordinates{X: 2, Y: 2}, Length: 2},
+			{Value: 633, Start: Coordinates{X: 6, Y: 2}, Length: 3},
+			{Value: 617, Start: Coordinates{X: 0, Y: 4}, Length: 3},
+			{Value: 58, Start: Coordinates{X: 7, Y: 5}, Length: 2},
+			{Value: 592, Start: Coordinates{X: 2, Y: 6}, Length: 3},
+			{Value: 755, Start: Coordinates{X: 6, Y: 7}, Length: 3},
+			{Value: 664, Start: Coordinates{X: 1, Y: 9}, Length: 3},
+			{Value: 598, Start: Coordinates{X: 5, Y: 9}, Length: 3},
 		},
 		Symbols: []Symbol{
 			{Coordinates: Coordinates{X: 3, Y: 1}, Value: "*"},
@@ -208,16 +208,16 @@ func TestComputeSumOfPartNumbers(t *testing.T) {
 func TestGetGears(t *testing.T) {
 	engineSchematic := EngineSchematic{
 		Numbers: []Number{
-			{Value: "467", DigitsCoordinates: []Coordinates{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}}},
-			{Value: "114", DigitsCoordinates: []Coordinates{{X: 5, Y: 0}, {X: 6, Y: 0}, {X: 7, Y: 0}}},
-			{Value: "35", DigitsCoordinates: []Coordinates{{X: 2, Y: 2}, {X: 3, Y: 2}}},
-			{Value: "633", DigitsCoordinates: []Coordinates{{X: 6, Y: 2}, {X: 7, Y: 2}, {X: 8, Y: 2}}},
-			{Value: "617", DigitsCoordinates: []Coordinates{{X: 0, Y: 4}, {X: 1, Y: 4}, {X: 2, Y: 4}}},
-			{Value: "58", DigitsCoordinates: []Coordinates{{X: 7, Y: 5}, {X: 8, Y: 5}}},
-			{Value: "592", DigitsCoordinates: []Coordinates{{X: 2, Y: 6}, {X: 3, Y: 6}, {X: 4, Y: 6}}},
-			{Value: "755", DigitsCoordinates: []Coordinates{{X: 6, Y: 7}, {X: 7, Y: 7}, {X: 8, Y: 7}}},
-			{Value: "664", DigitsCoordinates: []Coordinates{{X: 1, Y: 9}, {X: 2, Y: 9}, {X: 3, Y: 9}}},
-			{Value: "598", DigitsCoordinates: []Coordinates{{X: 5, Y: 9}, {X: 6, Y: 9}, {X: 7, Y: 9}}},
+			{Value: 467, Start: Coordinates{X: 0, Y: 0}, Length: 3},
+			{Value: 114, Start: Coordinates{X: 5, Y: 0}, Length: 3},
+			{Value: 35, Start: Coordinates{X: 2, Y: 2}, Length: 2},
+			{Value: 633, Start: Coordinates{X: 6, Y: 2}, Length: 3},
+			{Value: 617, Start: Coordinates{X: 0, Y: 4}, Length: 3},
+			{Value: 58, Start: Coordinates{X: 7, Y: 5}, Length: 2},
+			{Value: 592, Start: Coordinates{X: 2, Y: 6}, Length: 3},
+			{Value: 755, Start: Coordinates{X: 6, Y: 7}, Length: 3},
+			{Value: 664, Start: Coordinates{X: 1, Y: 9}, Length: 3},
+			{Value: 598, Start: Coordinates{X: 5, Y: 9}, Length: 3},
 		},
 		Symbols: []Symbol{
 			{Coordinates: Coordinates{X: 3, Y: 1}, Value: "*"},
@@ -230,8 +230,8 @@ func TestGetGears(t *testing.T) {
 	}
 
 	expectedGears := []Gear{
-		{Values: []int{467, 35}},
-		{Values: []int{755, 598}},
+		{Coordinates: Coordinates{X: 3, Y: 1}, NumberIDs: []int{0, 2}, Values: []int{467, 35}},
+		{Coordinates: Coordinates{X: 5, Y: 8}, NumberIDs: []int{7, 9}, Values: []int{755, 598}},
 	}
 	assert.Equal(t, expectedGears, engineSchematic.GetGears())
 }
@@ -239,16 +239,16 @@ func TestGetGears(t *testing.T) {
 func TestSumOfAllGearRatios(t *testing.T) {
 	engineSchematic := EngineSchematic{
 		Numbers: []Number{
-			{Value: "467", DigitsCoordinates: []Coordinates{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}}},
-			{Value: "114", DigitsCoordinates: []Coordinates{{X: 5, Y: 0}, {X: 6, Y: 0}, {X: 7, Y: 0}}},
-			{Value: "35", DigitsCoordinates: []Coordinates{{X: 2, Y: 2}, {X: 3, Y: 2}}},
-			{Value: "633", DigitsCoordinates: []Coordinates{{X: 6, Y: 2}, {X: 7, Y: 2}, {X: 8, Y: 2}}},
-			{Value: "617", DigitsCoordinates: []Coordinates{{X: 0, Y: 4}, {X: 1, Y: 4}, {X: 2, Y: 4}}},
-			{Value: "58", DigitsCoordinates: []Coordinates{{X: 7, Y: 5}, {X: 8, Y: 5}}},
-			{Value: "592", DigitsCoordinates: []Coordinates{{X: 2, Y: 6}, {X: 3, Y: 6}, {X: 4, Y: 6}}},
-			{Value: "755", DigitsCoordinates: []Coordinates{{X: 6, Y: 7}, {X: 7, Y: 7}, {X: 8, Y: 7}}},
-			{Value: "664", DigitsCoordinates: []Coordinates{{X: 1, Y: 9}, {X: 2, Y: 9}, {X: 3, Y: 9}}},
-			{Value: "598", DigitsCoordinates: []Coordinates{{X: 5, Y: 9}, {X: 6, Y: 9}, {X: 7, Y: 9}}},
+			{Value: 467, Start: Coordinates{X: 0, Y: 0}, Length: 3},
+			{Value: 114, Start: Coordinates{X: 5, Y: 0}, Length: 3},
+			{Value: 35, Start: Coordinates{X: 2, Y: 2}, Length: 2},
+			{Value: 633, Start: Coordinates{X: 6, Y: 2}, Length: 3},
+			{Value: 617, Start: Coordinates{X: 0, Y: 4}, Length: 3},
+			{Value: 58, Start: Coordinates{X: 7, Y: 5}, Length: 2},
+			{Value: 592, Start: Coordinates{X: 2, Y: 6}, Length: 3},
+			{Value: 755, Start: Coordinates{X: 6, Y: 7}, Length: 3},
+			{Value: 664, Start: Coordinates{X: 1, Y: 9}, Length: 3},
+			{Value: 598, Start: Coordinates{X: 5, Y: 9}, Length: 3},
 		},
 		Symbols: []Symbol{
 			{Coordinates: Coordinates{X: 3, Y: 1}, Value: "*"},
@@ -264,3 +264,255 @@ func TestSumOfAllGearRatios(t *testing.T) {
 
 	assert.Equal(t, expectedGearsRatioSum, engineSchematic.SumOfAllGearRatios())
 }
+
+func TestSumOfAllGearRatiosWithRule(t *testing.T) {
+	engineSchematic := ConvertInputToEngineSchematic([]string{
+		"467..114..",
+		"...*......",
+		"..35..633.",
+		"......#...",
+		"617*......",
+		".....+.58.",
+		"..592.....",
+		"......755.",
+		"...$.*....",
+		".664.598..",
+	})
+
+	type test struct {
+		rule GearRule
+		want int
+	}
+
+	tests := []test{
+		{DefaultGearRule, 467835},
+		{GearRule{Symbols: []string{"*"}, MinParts: 1, MaxParts: 2, Aggregation: GearSum}, 467 + 35 + 617 + 755 + 598},
+		{GearRule{Symbols: []string{"*", "#"}, MinParts: 1, MaxParts: 1, Aggregation: GearProduct}, 633 + 617},
+		{GearRule{Symbols: []string{"+", "$"}, MinParts: 1, MaxParts: 1, Aggregation: GearProduct}, 592 + 664},
+		{GearRule{Symbols: []string{"*"}, MinParts: 3, MaxParts: 8, Aggregation: GearProduct}, 0},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, engineSchematic.SumOfAllGearRatiosWithRule(tc.rule))
+	}
+}
+
+func TestGetGearsReadingOrder(t *testing.T) {
+	engineSchematic := EngineSchematic{
+		Numbers: []Number{
+			{Value: 2, Start: Coordinates{X: 0, Y: 0}, Length: 1},
+			{Value: 3, Start: Coordinates{X: 2, Y: 0}, Length: 1},
+			{Value: 4, Start: Coordinates{X: 0, Y: 2}, Length: 1},
+			{Value: 5, Start: Coordinates{X: 2, Y: 2}, Length: 1},
+		},
+		// Symbols given out of reading order
+		Symbols: []Symbol{
+			{Coordinates: Coordinates{X: 1, Y: 3}, Value: "*"},
+			{Coordinates: Coordinates{X: 1, Y: 1}, Value: "*"},
+		},
+	}
+
+	want := []Gear{
+		{Coordinates: Coordinates{X: 1, Y: 1}, NumberIDs: []int{0, 1, 2, 3}, Values: []int{2, 3, 4, 5}},
+		{Coordinates: Coordinates{X: 1, Y: 3}, NumberIDs: []int{2, 3}, Values: []int{4, 5}},
+	}
+
+	rule := DefaultGearRule
+	rule.MaxParts = 4
+	assert.Equal(t, want, engineSchematic.GetGearsWithRule(rule))
+	assert.Equal(t, want[1:], engineSchematic.GetGears())
+}
+
+func TestConvertInputToEngineSchematicWithOptions(t *testing.T) {
+	type test struct {
+		input   []string
+		options ParseOptions
+		want    EngineSchematic
+	}
+
+	tests := []test{
+		{
+			input:   []string{"-12..4-5", "..*.-..."},
+			options: DefaultParseOptions,
+			want: EngineSchematic{
+				Numbers: []Number{
+					{Value: 12, Start: Coordinates{X: 1, Y: 0}, Length: 2},
+					{Value: 4, Start: Coordinates{X: 5, Y: 0}, Length: 1},
+					{Value: 5, Start: Coordinates{X: 7, Y: 0}, Length: 1},
+				},
+				Symbols: []Symbol{
+					{Value: "-", Coordinates: Coordinates{X: 0, Y: 0}},
+					{Value: "-", Coordinates: Coordinates{X: 6, Y: 0}},
+					{Value: "*", Coordinates: Coordinates{X: 2, Y: 1}},
+					{Value: "-", Coordinates: Coordinates{X: 4, Y: 1}},
+				},
+			},
+		},
+		{
+			// A minus after a digit is still a symbol
+			input:   []string{"-12..4-5", "..*.-..."},
+			options: ParseOptions{Blanks: ".", SignedNumbers: true},
+			want: EngineSchematic{
+				Numbers: []Number{
+					{Value: -12, Start: Coordinates{X: 0, Y: 0}, Length: 3},
+					{Value: 4, Start: Coordinates{X: 5, Y: 0}, Length: 1},
+					{Value: 5, Start: Coordinates{X: 7, Y: 0}, Length: 1},
+				},
+				Symbols: []Symbol{
+					{Value: "-", Coordinates: Coordinates{X: 6, Y: 0}},
+					{Value: "*", Coordinates: Coordinates{X: 2, Y: 1}},
+					{Value: "-", Coordinates: Coordinates{X: 4, Y: 1}},
+				},
+			},
+		},
+		{
+			input:   []string{"7 _.#", "_ 8__"},
+			options: ParseOptions{Blanks: " _"},
+			want: EngineSchematic{
+				Numbers: []Number{
+					{Value: 7, Start: Coordinates{X: 0, Y: 0}, Length: 1},
+					{Value: 8, Start: Coordinates{X: 2, Y: 1}, Length: 1},
+				},
+				Symbols: []Symbol{
+					{Value: ".", Coordinates: Coordinates{X: 3, Y: 0}},
+					{Value: "#", Coordinates: Coordinates{X: 4, Y: 0}},
+				},
+			},
+		},
+		{
+			// Columns count runes rather than bytes
+			input:   []string{"é12→.", "..€.3"},
+			options: ParseOptions{Blanks: "."},
+			want: EngineSchematic{
+				Numbers: []Number{
+					{Value: 12, Start: Coordinates{X: 1, Y: 0}, Length: 2},
+					{Value: 3, Start: Coordinates{X: 4, Y: 1}, Length: 1},
+				},
+				Symbols: []Symbol{
+					{Value: "é", Coordinates: Coordinates{X: 0, Y: 0}},
+					{Value: "→", Coordinates: Coordinates{X: 3, Y: 0}},
+					{Value: "€", Coordinates: Coordinates{X: 2, Y: 1}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := ConvertInputToEngineSchematicWithOptions(tc.input, tc.options)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tc.want, got)
+	}
+
+	// 3 is diagonal to →, which it wouldn't be with byte columns
+	unicodeSchematic, _ := ConvertInputToEngineSchematicWithOptions(tests[3].input, tests[3].options)
+	assert.Equal(t, []int{12, 3}, unicodeSchematic.GetPartNumbersValues())
+
+	signedSchematic, _ := ConvertInputToEngineSchematicWithOptions(tests[1].input, tests[1].options)
+	assert.Equal(t, -12+4+5, signedSchematic.ComputeSumOfPartNumbers())
+}
+
+func TestConvertInputToEngineSchematicOverflow(t *testing.T) {
+	type test struct {
+		input   []string
+		options ParseOptions
+		wantErr string
+	}
+
+	tests := []test{
+		{
+			// math.MaxInt64 fits
+			input:   []string{"9223372036854775807*"},
+			options: DefaultParseOptions,
+			wantErr: "",
+		},
+		{
+			input:   []string{"1*", "..9223372036854775808"},
+			options: DefaultParseOptions,
+			wantErr: "line 2: number at column 3 overflows int",
+		},
+		{
+			input:   []string{".-99999999999999999999"},
+			options: ParseOptions{Blanks: ".", SignedNumbers: true},
+			wantErr: "line 1: number at column 2 overflows int",
+		},
+	}
+
+	for _, tc := range tests {
+		_, err := ConvertInputToEngineSchematicWithOptions(tc.input, tc.options)
+
+		if tc.wantErr == "" {
+			assert.NoError(t, err)
+		} else {
+			assert.EqualError(t, err, tc.wantErr)
+		}
+	}
+
+	assert.Panics(t, func() { ConvertInputToEngineSchematic(tests[1].input) })
+}
+
+func TestWrapNumbers(t *testing.T) {
+	type test struct {
+		input       []string
+		options     ParseOptions
+		want        []Number
+		partNumbers []int
+	}
+
+	wrap := ParseOptions{Blanks: ".", WrapNumbers: true}
+
+	tests := []test{
+		{
+			// Without the option, the lines are separate numbers
+			input:   []string{"....12", "34...."},
+			options: DefaultParseOptions,
+			want: []Number{
+				{Value: 12, Start: Coordinates{X: 4, Y: 0}, Length: 2},
+				{Value: 34, Start: Coordinates{X: 0, Y: 1}, Length: 2},
+			},
+			partNumbers: []int{},
+		},
+		{
+			// The symbol only touches the wrapped digits
+			input:   []string{"....12", "34....", "..*..."},
+			options: wrap,
+			want: []Number{
+				{Value: 1234, Start: Coordinates{X: 4, Y: 0}, Length: 2, Wraps: []int{2}},
+			},
+			partNumbers: []int{1234},
+		},
+		{
+			// A full line of digits wraps again
+			input:   []string{"..-1", "234", "5.*"},
+			options: ParseOptions{Blanks: ".", SignedNumbers: true, WrapNumbers: true},
+			want: []Number{
+				{Value: -12345, Start: Coordinates{X: 2, Y: 0}, Length: 2, Wraps: []int{3, 1}},
+			},
+			partNumbers: []int{-12345},
+		},
+		{
+			// Only numbers reaching the end of their line wrap
+			input:   []string{"12.", "34*"},
+			options: wrap,
+			want: []Number{
+				{Value: 12, Start: Coordinates{X: 0, Y: 0}, Length: 2},
+				{Value: 34, Start: Coordinates{X: 0, Y: 1}, Length: 2},
+			},
+			partNumbers: []int{12, 34},
+		},
+	}
+
+	for _, tc := range tests {
+		got, err := ConvertInputToEngineSchematicWithOptions(tc.input, tc.options)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tc.want, got.Numbers)
+		assert.Equal(t, tc.partNumbers, got.GetPartNumbersValues())
+	}
+
+	wrapped, _ := ConvertInputToEngineSchematicWithOptions(tests[1].input, tests[1].options)
+	assert.Equal(t, []Coordinates{{X: 4, Y: 0}, {X: 5, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}}, wrapped.Numbers[0].DigitsCoordinates())
+	assert.Equal(t, []Component{
+		{NumberIDs: []int{0}, SymbolIDs: []int{0}, Min: Coordinates{X: 0, Y: 0}, Max: Coordinates{X: 5, Y: 2}, PartValue: 1234},
+	}, wrapped.Components(EightConnectivity))
+}
@@ -0,0 +1,149 @@
+package day03
+
+import (
+	"slices"
+)
+
+// Connectivity tells which neighbours of a cell are adjacent to it:
+// the 4 orthogonal ones, or the 8 including diagonals.
+type Connectivity int
+
+const (
+	FourConnectivity  Connectivity = 4
+	EightConnectivity Connectivity = 8
+)
+
+// Component is a group of numbers and symbols linked to each other through
+// chains of adjacent elements.
+type Component struct {
+	// NumberIDs and SymbolIDs are positions in EngineSchematic.Numbers
+	// and EngineSchematic.Symbols, in increasing order
+	NumberIDs []int
+	SymbolIDs []int
+
+	// Min and Max are the top-left and bottom-right cells of the bounding box
+	Min Coordinates
+	Max Coordinates
+
+	// PartValue sums the part numbers of the component. Whether a number is a
+	// part number doesn't depend on the connectivity: with 4-connectivity,
+	// the symbol making it one may be diagonal to it, in another component.
+	PartValue int
+}
+
+func (nb Number) GetOrthogonallyAdjacentCoordinates() []Coordinates {
+	adjacentCoordinates := make([]Coordinates, 0)
+
+	for _, digits := range nb.DigitsCoordinates() {
+		adjacentCoordinates = append(adjacentCoordinates, Coordinates{digits.X, digits.Y - 1})
+		adjacentCoordinates = append(adjacentCoordinates, Coordinates{digits.X + 1, digits.Y})
+		adjacentCoordinates = append(adjacentCoordinates, Coordinates{digits.X, digits.Y + 1})
+		adjacentCoordinates = append(adjacentCoordinates, Coordinates{digits.X - 1, digits.Y})
+	}
+
+	return adjacentCoordinates
+}
+
+// adjacentCoordinates returns the cells around the element, which may
+// include cells of the element itself.
+func (nb Number) adjacentCoordinates(connectivity Connectivity) []Coordinates {
+	if connectivity == FourConnectivity {
+		return nb.GetOrthogonallyAdjacentCoordinates()
+	}
+
+	return nb.GetAllAdjacentCoordinates()
+}
+
+func (symbol Symbol) adjacentCoordinates(connectivity Connectivity) []Coordinates {
+	// A symbol is a number of length 1 as far as neighbours are concerned
+	return Number{Start: symbol.Coordinates, Length: 1}.adjacentCoordinates(connectivity)
+}
+
+// Components groups the numbers and symbols of the schematic, ordering the
+// components by their first cell in reading order.
+func (es EngineSchematic) Components(connectivity Connectivity) []Component {
+	index := es.coordinateIndex()
+
+	// Numbers come first in the union-find forest, then symbols
+	parents := make([]int, len(es.Numbers)+len(es.Symbols))
+	for element := range parents {
+		parents[element] = element
+	}
+
+	find := func(element int) int {
+		for parents[element] != element {
+			parents[element] = parents[parents[element]]
+			element = parents[element]
+		}
+
+		return element
+	}
+
+	unionAround := func(element int, neighbours []Coordinates) {
+		for _, neighbour := range neighbours {
+			if id, ok := index.numberAt(neighbour); ok {
+				parents[find(id)] = find(element)
+			}
+			if id, ok := index.symbolAt(neighbour); ok {
+				parents[find(len(es.Numbers)+id)] = find(element)
+			}
+		}
+	}
+
+	for id, number := range es.Numbers {
+		unionAround(id, number.adjacentCoordinates(connectivity))
+	}
+	for id, symbol := range es.Symbols {
+		unionAround(len(es.Numbers)+id, symbol.adjacentCoordinates(connectivity))
+	}
+
+	components := []*Component{}
+	componentOfRoot := map[int]*Component{}
+	first := map[*Component]Coordinates{}
+
+	componentOf := func(element int, topLeft, bottomRight Coordinates) *Component {
+		component, ok := componentOfRoot[find(element)]
+		if !ok {
+			component = &Component{NumberIDs: []int{}, SymbolIDs: []int{}, Min: topLeft, Max: bottomRight}
+			componentOfRoot[find(element)] = component
+			components = append(components, component)
+			first[component] = topLeft
+		}
+
+		component.Min = Coordinates{X: min(component.Min.X, topLeft.X), Y: min(component.Min.Y, topLeft.Y)}
+		component.Max = Coordinates{X: max(component.Max.X, bottomRight.X), Y: max(component.Max.Y, bottomRight.Y)}
+		if topLeft.Compare(first[component]) < 0 {
+			first[component] = topLeft
+		}
+
+		return component
+	}
+
+	for id, number := range es.Numbers {
+		component := (*Component)(nil)
+		for i := 0; i < number.segmentCount(); i++ {
+			start, length := number.segment(i)
+			component = componentOf(id, start, Coordinates{X: start.X + length - 1, Y: start.Y})
+		}
+
+		component.NumberIDs = append(component.NumberIDs, id)
+		if index.touchesSymbol(number) {
+			component.PartValue += number.Value
+		}
+	}
+	for id, symbol := range es.Symbols {
+		component := componentOf(len(es.Numbers)+id, symbol.Coordinates, symbol.Coordinates)
+		component.SymbolIDs = append(component.SymbolIDs, id)
+	}
+
+	slices.SortStableFunc(components, func(a, b *Component) int {
+		return first[a].Compare(first[b])
+	})
+
+	result := make([]Component, 0, len(components))
+	for _, component := range components {
+		result = append(result, *component)
+	}
+
+	return result
+}
@@ -0,0 +1,97 @@
+package day03
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComponents(t *testing.T) {
+	engineSchematic := ConvertInputToEngineSchematic([]string{
+		"12.3",
+		"..#.",
+		"4...",
+		".*5.",
+	})
+
+	type test struct {
+		connectivity Connectivity
+		want         []Component
+	}
+
+	tests := []test{
+		{
+			EightConnectivity,
+			[]Component{
+				{NumberIDs: []int{0, 1}, SymbolIDs: []int{0}, Min: Coordinates{X: 0, Y: 0}, Max: Coordinates{X: 3, Y: 1}, PartValue: 15},
+				{NumberIDs: []int{2, 3}, SymbolIDs: []int{1}, Min: Coordinates{X: 0, Y: 2}, Max: Coordinates{X: 2, Y: 3}, PartValue: 9},
+			},
+		},
+		{
+			// Diagonal neighbours are no longer linked, but still make
+			// part numbers
+			FourConnectivity,
+			[]Component{
+				{NumberIDs: []int{0}, SymbolIDs: []int{}, Min: Coordinates{X: 0, Y: 0}, Max: Coordinates{X: 1, Y: 0}, PartValue: 12},
+				{NumberIDs: []int{1}, SymbolIDs: []int{}, Min: Coordinates{X: 3, Y: 0}, Max: Coordinates{X: 3, Y: 0}, PartValue: 3},
+				{NumberIDs: []int{}, SymbolIDs: []int{0}, Min: Coordinates{X: 2, Y: 1}, Max: Coordinates{X: 2, Y: 1}, PartValue: 0},
+				{NumberIDs: []int{2}, SymbolIDs: []int{}, Min: Coordinates{X: 0, Y: 2}, Max: Coordinates{X: 0, Y: 2}, PartValue: 4},
+				{NumberIDs: []int{3}, SymbolIDs: []int{1}, Min: Coordinates{X: 1, Y: 3}, Max: Coordinates{X: 2, Y: 3}, PartValue: 5},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.want, engineSchematic.Components(tc.connectivity))
+	}
+}
+
+func TestComponentsChains(t *testing.T) {
+	// Numbers on consecutive rows and symbols next to each other
+	// link elements that are far apart
+	engineSchematic := ConvertInputToEngineSchematic([]string{
+		"7.....",
+		".12...",
+		"...34.",
+		"......",
+		"#$%..9",
+	})
+
+	components := engineSchematic.Components(EightConnectivity)
+
+	assert.Len(t, components, 3)
+	assert.Equal(t, []int{0, 1, 2}, components[0].NumberIDs)
+	assert.Equal(t, 0, components[0].PartValue)
+	assert.Equal(t, Coordinates{X: 4, Y: 2}, components[0].Max)
+	assert.Equal(t, []int{0, 1, 2}, components[1].SymbolIDs)
+	assert.Equal(t, []int{3}, components[2].NumberIDs)
+
+}
+
+func TestComponentsPartValue(t *testing.T) {
+	type test struct {
+		input []string
+		want  int
+	}
+
+	// Every part number belongs to exactly one component
+	tests := []test{
+		{input: readPuzzleInput(t), want: 521515},
+		{input: []string{"1.2", ".*.", "3.4"}, want: 1 + 2 + 3 + 4},
+		{input: []string{"12*34", "....5", "6...#"}, want: 12 + 34 + 5},
+		{input: []string{"....*", "1....", "....7"}, want: 0},
+	}
+
+	for _, tc := range tests {
+		engineSchematic := ConvertInputToEngineSchematic(tc.input)
+
+		for _, connectivity := range []Connectivity{FourConnectivity, EightConnectivity} {
+			sum := 0
+			for _, component := range engineSchematic.Components(connectivity) {
+				sum += component.PartValue
+			}
+
+			assert.Equal(t, tc.want, sum)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package day03
+
+import (
+	"fmt"
+	"slices"
+)
+
+type Config struct {
+	Parse ParseOptions `json:"parse"`
+	Gear  GearRule     `json:"gear"`
+}
+
+func DefaultConfig() Config {
+	gear := DefaultGearRule
+	gear.Symbols = slices.Clone(DefaultGearRule.Symbols)
+
+	return Config{
+		Parse: DefaultParseOptions,
+		Gear:  gear,
+	}
+}
+
+func (config Config) Validate() error {
+	if err := config.Parse.Validate(); err != nil {
+		return fmt.Errorf("parse: %w", err)
+	}
+
+	if err := config.Gear.Validate(); err != nil {
+		return fmt.Errorf("gear: %w", err)
+	}
+
+	return nil
+}
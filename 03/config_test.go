@@ -0,0 +1,36 @@
+package day03
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, DefaultConfig().Validate())
+
+	config := DefaultConfig()
+	config.Gear.Symbols = nil
+	assert.EqualError(t, config.Validate(), "gear: gear rule has no symbols")
+
+	config = DefaultConfig()
+	config.Gear.Symbols = []string{"*", "7"}
+	assert.EqualError(t, config.Validate(), `gear: gear symbol "7" is not a symbol`)
+
+	config = DefaultConfig()
+	config.Gear.MinParts = 0
+	assert.EqualError(t, config.Validate(), "gear: gears need at least 1 part, got minParts 0")
+
+	config = DefaultConfig()
+	config.Gear.MaxParts = 1
+	assert.EqualError(t, config.Validate(), "gear: maxParts 1 is less than minParts 2")
+
+	config = DefaultConfig()
+	config.Gear.Aggregation = "average"
+	assert.EqualError(t, config.Validate(), `gear: unknown gear aggregation "average"`)
+
+	// Defaults must not share the package-level rule
+	config = DefaultConfig()
+	config.Gear.Symbols[0] = "#"
+	assert.Equal(t, "*", DefaultGearRule.Symbols[0])
+}
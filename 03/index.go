@@ -0,0 +1,141 @@
+package day03
+
+import (
+	"slices"
+)
+
+// schematicIndex is a dense grid telling which number and which symbol
+// cover each cell of the schematic, so that neighbours are found
+// without going through every number or every symbol.
+type schematicIndex struct {
+	width, height int
+
+	// numbers and symbols hold the position in EngineSchematic.Numbers and
+	// EngineSchematic.Symbols of what covers each cell, plus one (0 is none)
+	numbers []int32
+	symbols []int32
+}
+
+func newSchematicIndex(numbers []Number, symbols []Symbol) *schematicIndex {
+	index := &schematicIndex{}
+
+	for _, number := range numbers {
+		for i := 0; i < number.segmentCount(); i++ {
+			start, length := number.segment(i)
+			index.width = max(index.width, start.X+length)
+			index.height = max(index.height, start.Y+1)
+		}
+	}
+	for _, symbol := range symbols {
+		index.width = max(index.width, symbol.Coordinates.X+1)
+		index.height = max(index.height, symbol.Coordinates.Y+1)
+	}
+
+	index.numbers = make([]int32, index.width*index.height)
+	index.symbols = make([]int32, index.width*index.height)
+
+	for id, number := range numbers {
+		for i := 0; i < number.segmentCount(); i++ {
+			start, length := number.segment(i)
+			for x := start.X; x < start.X+length; x++ {
+				index.numbers[index.cell(Coordinates{x, start.Y})] = int32(id + 1)
+			}
+		}
+	}
+	for id, symbol := range symbols {
+		index.symbols[index.cell(symbol.Coordinates)] = int32(id + 1)
+	}
+
+	return index
+}
+
+func (index *schematicIndex) cell(coordinates Coordinates) int {
+	return coordinates.Y*index.width + coordinates.X
+}
+
+func (index *schematicIndex) contains(coordinates Coordinates) bool {
+	return coordinates.X >= 0 && coordinates.X < index.width &&
+		coordinates.Y >= 0 && coordinates.Y < index.height
+}
+
+func (index *schematicIndex) numberAt(coordinates Coordinates) (int, bool) {
+	if !index.contains(coordinates) {
+		return 0, false
+	}
+
+	id := index.numbers[index.cell(coordinates)]
+	return int(id) - 1, id != 0
+}
+
+func (index *schematicIndex) symbolAt(coordinates Coordinates) (int, bool) {
+	if !index.contains(coordinates) {
+		return 0, false
+	}
+
+	id := index.symbols[index.cell(coordinates)]
+	return int(id) - 1, id != 0
+}
+
+// touchesSymbol tells whether a symbol is among the neighbours
+// of a digit of number.
+func (index *schematicIndex) touchesSymbol(number Number) bool {
+	for i := 0; i < number.segmentCount(); i++ {
+		start, length := number.segment(i)
+
+		for y := start.Y - 1; y <= start.Y+1; y++ {
+			for x := start.X - 1; x <= start.X+length; x++ {
+				if _, ok := index.symbolAt(Coordinates{x, y}); ok {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// symbolsAround returns the IDs of the symbols among the neighbours
+// of the digits of number, each once and in increasing order.
+func (index *schematicIndex) symbolsAround(number Number) []int {
+	ids := []int{}
+
+	for i := 0; i < number.segmentCount(); i++ {
+		start, length := number.segment(i)
+
+		for y := start.Y - 1; y <= start.Y+1; y++ {
+			for x := start.X - 1; x <= start.X+length; x++ {
+				if id, ok := index.symbolAt(Coordinates{x, y}); ok && !slices.Contains(ids, id) {
+					ids = append(ids, id)
+				}
+			}
+		}
+	}
+
+	slices.Sort(ids)
+
+	return ids
+}
+
+// numbersAround returns the IDs of the numbers with a digit among the
+// 8 neighbours of coordinates, each once and in increasing order.
+func (index *schematicIndex) numbersAround(coordinates Coordinates) []int {
+	ids := []int{}
+
+	for y := coordinates.Y - 1; y <= coordinates.Y+1; y++ {
+		for x := coordinates.X - 1; x <= coordinates.X+1; x++ {
+			if id, ok := index.numberAt(Coordinates{x, y}); ok && !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	slices.Sort(ids)
+
+	return ids
+}
+
+// coordinateIndex builds the index of the schematic. Queries build their
+// own, so that it never goes stale when Numbers or Symbols are edited.
+func (es EngineSchematic) coordinateIndex() *schematicIndex {
+	return newSchematicIndex(es.Numbers, es.Symbols)
+}
@@ -0,0 +1,209 @@
+package day03
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexEdges(t *testing.T) {
+	type test struct {
+		input           []string
+		wantPartNumbers []int
+		wantGearRatios  int
+	}
+
+	tests := []test{
+		{
+			// Diagonals around a symbol in the middle
+			input:           []string{"1.2", ".*.", "3.4"},
+			wantPartNumbers: []int{1, 2, 3, 4},
+			wantGearRatios:  0,
+		},
+		{
+			// A symbol between two numbers on the same line
+			input:           []string{"12*34", ".....", "5...#"},
+			wantPartNumbers: []int{12, 34},
+			wantGearRatios:  12 * 34,
+		},
+		{
+			// Only the first digit touches the symbol
+			input:           []string{"..123", ".+..."},
+			wantPartNumbers: []int{123},
+			wantGearRatios:  0,
+		},
+		{
+			// Nothing wraps around the end of a line
+			input:           []string{"....*", "1....", "....7"},
+			wantPartNumbers: []int{},
+			wantGearRatios:  0,
+		},
+		{
+			// A number touched by two gears counts in both
+			input:           []string{"2.3.4", ".*.*.", "....."},
+			wantPartNumbers: []int{2, 3, 4},
+			wantGearRatios:  2*3 + 3*4,
+		},
+	}
+
+	for _, tc := range tests {
+		engineSchematic := ConvertInputToEngineSchematic(tc.input)
+
+		assert.Equal(t, tc.wantPartNumbers, engineSchematic.GetPartNumbersValues(), tc.input)
+		assert.Equal(t, tc.wantGearRatios, engineSchematic.SumOfAllGearRatios(), tc.input)
+	}
+}
+
+// generateSchematic draws a size×size schematic of short numbers and
+// symbols scattered among periods.
+func generateSchematic(size int, seed int64) []string {
+	random := rand.New(rand.NewSource(seed))
+	symbols := "*#+$/@%&=-"
+
+	input := make([]string, 0, size)
+	for y := 0; y < size; y++ {
+		line := strings.Builder{}
+
+		for line.Len() < size {
+			switch roll := random.Float64(); {
+			case roll < 0.15:
+				for i := 0; i < 1+random.Intn(3) && line.Len() < size; i++ {
+					line.WriteByte(byte('0' + random.Intn(10)))
+				}
+				if line.Len() < size {
+					line.WriteByte('.')
+				}
+			case roll < 0.21:
+				line.WriteByte(symbols[random.Intn(len(symbols))])
+			default:
+				line.WriteByte('.')
+			}
+		}
+
+		input = append(input, line.String())
+	}
+
+	return input
+}
+
+func isAdjacent(number Number, coordinates Coordinates) bool {
+	for _, digit := range number.DigitsCoordinates() {
+		if abs(digit.X-coordinates.X) <= 1 && abs(digit.Y-coordinates.Y) <= 1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
+
+func TestIndexMatchesNaive(t *testing.T) {
+	engineSchematic := ConvertInputToEngineSchematic(generateSchematic(120, 1))
+
+	naivePartNumbers := []int{}
+	for _, number := range engineSchematic.Numbers {
+		for _, symbol := range engineSchematic.Symbols {
+			if isAdjacent(number, symbol.Coordinates) {
+				naivePartNumbers = append(naivePartNumbers, number.Value)
+				break
+			}
+		}
+	}
+
+	naiveGearRatios := 0
+	for _, symbol := range engineSchematic.Symbols {
+		if symbol.Value != "*" {
+			continue
+		}
+
+		adjacent := []int{}
+		for _, number := range engineSchematic.Numbers {
+			if isAdjacent(number, symbol.Coordinates) {
+				adjacent = append(adjacent, number.Value)
+			}
+		}
+		if len(adjacent) == 2 {
+			naiveGearRatios += adjacent[0] * adjacent[1]
+		}
+	}
+
+	assert.Equal(t, naivePartNumbers, engineSchematic.GetPartNumbersValues())
+	assert.Equal(t, naiveGearRatios, engineSchematic.SumOfAllGearRatios())
+	assert.NotZero(t, naiveGearRatios)
+}
+
+// readPuzzleInput returns the embedded puzzle input
+func readPuzzleInput(tb testing.TB) []string {
+	input, err := utils.ReadInput(inputs, &utils.Options{})
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	return input
+}
+
+func BenchmarkConvertInputToEngineSchematic(b *testing.B) {
+	input := generateSchematic(1000, 1)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		ConvertInputToEngineSchematic(input)
+	}
+}
+
+func BenchmarkComputeSumOfPartNumbers(b *testing.B) {
+	engineSchematic := ConvertInputToEngineSchematic(generateSchematic(1000, 1))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		engineSchematic.ComputeSumOfPartNumbers()
+	}
+}
+
+func BenchmarkSumOfAllGearRatios(b *testing.B) {
+	engineSchematic := ConvertInputToEngineSchematic(generateSchematic(1000, 1))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		engineSchematic.SumOfAllGearRatios()
+	}
+}
+
+func TestIndexFollowsEdits(t *testing.T) {
+	engineSchematic := ConvertInputToEngineSchematic([]string{"12...", ".....", "..34."})
+	assert.Equal(t, []int{}, engineSchematic.GetPartNumbersValues())
+
+	engineSchematic.Symbols = append(engineSchematic.Symbols, Symbol{Coordinates: Coordinates{X: 2, Y: 1}, Value: "*"})
+	assert.Equal(t, []int{12, 34}, engineSchematic.GetPartNumbersValues())
+	assert.Equal(t, 12*34, engineSchematic.SumOfAllGearRatios())
+
+	engineSchematic.Numbers = engineSchematic.Numbers[:1]
+	assert.Equal(t, []int{12}, engineSchematic.GetPartNumbersValues())
+}
+
+func TestTouchesSymbolAllocations(t *testing.T) {
+	engineSchematic, err := ConvertInputToEngineSchematicWithOptions(
+		[]string{"467..114..", "...*.....6", "7.35..633."},
+		ParseOptions{Blanks: ".", WrapNumbers: true},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, engineSchematic.Numbers[2].Wraps)
+	index := engineSchematic.coordinateIndex()
+
+	allocations := testing.AllocsPerRun(100, func() {
+		for _, number := range engineSchematic.Numbers {
+			index.touchesSymbol(number)
+		}
+	})
+	assert.Zero(t, allocations)
+}
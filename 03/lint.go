@@ -0,0 +1,24 @@
+package day03
+
+import (
+	"errors"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+)
+
+func LintInput(input []string, options ParseOptions) []utils.LintProblem {
+	// Anything visible is either a digit, a blank or a symbol
+	problems := utils.LintGridFunc(input, func(char rune) bool {
+		return options.isBlank(char) ||
+			char != utf8.RuneError && unicode.IsGraphic(char) && !unicode.IsSpace(char)
+	})
+
+	overflow := &NumberOverflowError{}
+	if _, err := ConvertInputToEngineSchematicWithOptions(input, options); errors.As(err, &overflow) {
+		problems = append(problems, utils.Lintf(overflow.Line, "number at column %d overflows int", overflow.Column))
+	}
+
+	return problems
+}
@@ -0,0 +1,40 @@
+package day03
+
+import (
+	"testing"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintInput(t *testing.T) {
+	input := []string{
+		"467..→",
+		"...*..",
+		"..35 .",
+		"..12.",
+	}
+
+	want := []utils.LintProblem{
+		{Line: 3, Message: "unexpected character ' ' at column 5"},
+		{Line: 4, Message: "width is 5, expected 6 like line 1"},
+	}
+	assert.Equal(t, want, LintInput(input, DefaultParseOptions))
+
+	want = []utils.LintProblem{
+		{Line: 4, Message: "width is 5, expected 6 like line 1"},
+	}
+	assert.Equal(t, want, LintInput(input, ParseOptions{Blanks: ". "}))
+}
+
+func TestLintInputOverflow(t *testing.T) {
+	input := []string{
+		"1*....................",
+		"..99999999999999999999",
+	}
+
+	want := []utils.LintProblem{
+		{Line: 2, Message: "number at column 3 overflows int"},
+	}
+	assert.Equal(t, want, LintInput(input, DefaultParseOptions))
+}
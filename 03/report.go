@@ -0,0 +1,156 @@
+package day03
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// NumberReport is a number along with the symbols adjacent to it.
+type NumberReport struct {
+	ID      int      `json:"id"`
+	Number  Number   `json:"number"`
+	Symbols []Symbol `json:"symbols"`
+}
+
+// SymbolReport is a symbol along with the numbers adjacent to it.
+type SymbolReport struct {
+	ID      int      `json:"id"`
+	Symbol  Symbol   `json:"symbol"`
+	Numbers []Number `json:"numbers"`
+}
+
+type SchematicReport struct {
+	PartNumbers    []NumberReport `json:"partNumbers"`
+	NonPartNumbers []NumberReport `json:"nonPartNumbers"`
+
+	// SymbolCounts counts the symbols of each kind
+	SymbolCounts map[string]int `json:"symbolCounts"`
+
+	// LonelySymbols aren't adjacent to any number
+	LonelySymbols []SymbolReport `json:"lonelySymbols"`
+
+	// SharedNumbers are adjacent to more than one symbol
+	SharedNumbers []NumberReport `json:"sharedNumbers"`
+
+	// NearMissGears are gear symbols with one part number too few
+	// or too many to be gears
+	NearMissGears []SymbolReport `json:"nearMissGears"`
+}
+
+// Report goes through every number and symbol of the schematic, in reading
+// order, with near-miss gears judged by rule.
+func (es EngineSchematic) Report(rule GearRule) SchematicReport {
+	index := es.coordinateIndex()
+
+	report := SchematicReport{
+		PartNumbers:    []NumberReport{},
+		NonPartNumbers: []NumberReport{},
+		SymbolCounts:   map[string]int{},
+		LonelySymbols:  []SymbolReport{},
+		SharedNumbers:  []NumberReport{},
+		NearMissGears:  []SymbolReport{},
+	}
+
+	for id, number := range es.Numbers {
+		numberReport := NumberReport{ID: id, Number: number, Symbols: []Symbol{}}
+		for _, symbolID := range index.symbolsAround(number) {
+			numberReport.Symbols = append(numberReport.Symbols, es.Symbols[symbolID])
+		}
+
+		switch {
+		case len(numberReport.Symbols) == 0:
+			report.NonPartNumbers = append(report.NonPartNumbers, numberReport)
+		case len(numberReport.Symbols) > 1:
+			report.SharedNumbers = append(report.SharedNumbers, numberReport)
+			fallthrough
+		default:
+			report.PartNumbers = append(report.PartNumbers, numberReport)
+		}
+	}
+
+	for id, symbol := range es.Symbols {
+		report.SymbolCounts[symbol.Value]++
+
+		symbolReport := SymbolReport{ID: id, Symbol: symbol, Numbers: []Number{}}
+		for _, numberID := range index.numbersAround(symbol.Coordinates) {
+			symbolReport.Numbers = append(symbolReport.Numbers, es.Numbers[numberID])
+		}
+
+		if len(symbolReport.Numbers) == 0 {
+			report.LonelySymbols = append(report.LonelySymbols, symbolReport)
+		}
+
+		partsCount := len(symbolReport.Numbers)
+		if slices.Contains(rule.Symbols, symbol.Value) && (partsCount == rule.MinParts-1 || partsCount == rule.MaxParts+1) {
+			report.NearMissGears = append(report.NearMissGears, symbolReport)
+		}
+	}
+
+	return report
+}
+
+// WriteTable writes each section of the report as an aligned table.
+func (report SchematicReport) WriteTable(out io.Writer) error {
+	table := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+
+	writeNumbers := func(title string, numbers []NumberReport) {
+		fmt.Fprintf(table, "%s (%d)\n", title, len(numbers))
+		fmt.Fprintln(table, "ID\tVALUE\tSTART\tLENGTH\tSYMBOLS")
+		for _, number := range numbers {
+			symbols := make([]string, 0, len(number.Symbols))
+			for _, symbol := range number.Symbols {
+				symbols = append(symbols, fmt.Sprintf("%s %s", symbol.Value, symbol.Coordinates))
+			}
+
+			fmt.Fprintf(table, "%d\t%d\t%s\t%d\t%s\n", number.ID, number.Number.Value, number.Number.Start, number.Number.Length, joinOrDash(symbols))
+		}
+		fmt.Fprintln(table)
+	}
+
+	writeSymbols := func(title string, symbols []SymbolReport) {
+		fmt.Fprintf(table, "%s (%d)\n", title, len(symbols))
+		fmt.Fprintln(table, "ID\tSYMBOL\tCOORDINATES\tNUMBERS")
+		for _, symbol := range symbols {
+			numbers := make([]string, 0, len(symbol.Numbers))
+			for _, number := range symbol.Numbers {
+				numbers = append(numbers, fmt.Sprintf("%d %s", number.Value, number.Start))
+			}
+
+			fmt.Fprintf(table, "%d\t%s\t%s\t%s\n", symbol.ID, symbol.Symbol.Value, symbol.Symbol.Coordinates, joinOrDash(numbers))
+		}
+		fmt.Fprintln(table)
+	}
+
+	writeNumbers("Part numbers", report.PartNumbers)
+	writeNumbers("Non-part numbers", report.NonPartNumbers)
+
+	symbolValues := make([]string, 0, len(report.SymbolCounts))
+	for value := range report.SymbolCounts {
+		symbolValues = append(symbolValues, value)
+	}
+	slices.Sort(symbolValues)
+
+	fmt.Fprintf(table, "Symbols (%d kinds)\n", len(symbolValues))
+	fmt.Fprintln(table, "SYMBOL\tCOUNT")
+	for _, value := range symbolValues {
+		fmt.Fprintf(table, "%s\t%d\n", value, report.SymbolCounts[value])
+	}
+	fmt.Fprintln(table)
+
+	writeSymbols("Lonely symbols", report.LonelySymbols)
+	writeNumbers("Numbers touching several symbols", report.SharedNumbers)
+	writeSymbols("Near-miss gears", report.NearMissGears)
+
+	return table.Flush()
+}
+
+func joinOrDash(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+
+	return strings.Join(values, " ")
+}
@@ -0,0 +1,94 @@
+package day03
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReport(t *testing.T) {
+	engineSchematic := ConvertInputToEngineSchematic([]string{
+		"1*2..#",
+		"..*3..",
+		"4.....",
+		"*..5..",
+	})
+
+	one := Number{Value: 1, Start: Coordinates{X: 0, Y: 0}, Length: 1}
+	two := Number{Value: 2, Start: Coordinates{X: 2, Y: 0}, Length: 1}
+	three := Number{Value: 3, Start: Coordinates{X: 3, Y: 1}, Length: 1}
+	four := Number{Value: 4, Start: Coordinates{X: 0, Y: 2}, Length: 1}
+	five := Number{Value: 5, Start: Coordinates{X: 3, Y: 3}, Length: 1}
+
+	firstGear := Symbol{Value: "*", Coordinates: Coordinates{X: 1, Y: 0}}
+	hash := Symbol{Value: "#", Coordinates: Coordinates{X: 5, Y: 0}}
+	secondGear := Symbol{Value: "*", Coordinates: Coordinates{X: 2, Y: 1}}
+	nearMiss := Symbol{Value: "*", Coordinates: Coordinates{X: 0, Y: 3}}
+
+	want := SchematicReport{
+		PartNumbers: []NumberReport{
+			{ID: 0, Number: one, Symbols: []Symbol{firstGear}},
+			{ID: 1, Number: two, Symbols: []Symbol{firstGear, secondGear}},
+			{ID: 2, Number: three, Symbols: []Symbol{secondGear}},
+			{ID: 3, Number: four, Symbols: []Symbol{nearMiss}},
+		},
+		NonPartNumbers: []NumberReport{
+			{ID: 4, Number: five, Symbols: []Symbol{}},
+		},
+		SymbolCounts: map[string]int{"*": 3, "#": 1},
+		LonelySymbols: []SymbolReport{
+			{ID: 1, Symbol: hash, Numbers: []Number{}},
+		},
+		SharedNumbers: []NumberReport{
+			{ID: 1, Number: two, Symbols: []Symbol{firstGear, secondGear}},
+		},
+		NearMissGears: []SymbolReport{
+			{ID: 3, Symbol: nearMiss, Numbers: []Number{four}},
+		},
+	}
+
+	report := engineSchematic.Report(DefaultGearRule)
+	assert.Equal(t, want, report)
+
+	table := strings.Builder{}
+	assert.NoError(t, report.WriteTable(&table))
+	assert.Equal(t, `Part numbers (4)
+ID  VALUE  START   LENGTH  SYMBOLS
+0   1      (0, 0)  1       * (1, 0)
+1   2      (2, 0)  1       * (1, 0) * (2, 1)
+2   3      (3, 1)  1       * (2, 1)
+3   4      (0, 2)  1       * (0, 3)
+
+Non-part numbers (1)
+ID  VALUE  START   LENGTH  SYMBOLS
+4   5      (3, 3)  1       -
+
+Symbols (2 kinds)
+SYMBOL  COUNT
+#       1
+*       3
+
+Lonely symbols (1)
+ID  SYMBOL  COORDINATES  NUMBERS
+1   #       (5, 0)       -
+
+Numbers touching several symbols (1)
+ID  VALUE  START   LENGTH  SYMBOLS
+1   2      (2, 0)  1       * (1, 0) * (2, 1)
+
+Near-miss gears (1)
+ID  SYMBOL  COORDINATES  NUMBERS
+3   *       (0, 3)       4 (0, 2)
+
+`, table.String())
+
+	data, err := json.Marshal(report)
+	assert.NoError(t, err)
+
+	decoded := SchematicReport{}
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, report, decoded)
+	assert.Contains(t, string(data), `"nearMissGears":[{"id":3,"symbol":{"value":"*","coordinates":{"x":0,"y":3}},"numbers":[{"value":4,"start":{"x":0,"y":2},"length":1}]}]`)
+}
@@ -1,22 +1,72 @@
-package main
+package day04
 
 import (
+	"cmp"
+	"embed"
+	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/angristan/advent-of-code-2023/utils"
 )
 
-func main() {
-	input := utils.ParseInput("input.txt")
+//go:embed input.txt example*.txt
+var inputs embed.FS
+
+// Run prints the points and the count of cards won. With many matches,
+// the count is printed from arbitrary precision counting.
+func Run(args []string) error {
+	config := utils.NoConfig{}
+
+	return utils.DayRunner{
+		Day:    "04",
+		Inputs: inputs,
+		Config: &config,
+		Lint:   LintInput,
+		Solve: func(input []string) error {
+			cards, err := ConvertInputToListOfCards(input)
+			if err != nil {
+				return err
+			}
 
-	cards := ConvertInputToListOfCards(input)
-	part1Score := cards.ComputeTotalPoints()
-	fmt.Printf("Part 1: %d\n", part1Score)
+			// Both parts reuse the same match counts
+			matched := cards.Match()
 
-	part2Score := cards.ComputeTotalCardsCount()
-	fmt.Printf("Part 2: %d\n", part2Score)
+			part1Score := matched.ComputeTotalPointsWithRule(DefaultScoringRule)
+			fmt.Printf("Part 1: %d\n", part1Score)
+
+			part2Score, err := matched.ComputeTotalCardsCountWithRule(DefaultCopyRule)
+			if errors.Is(err, ErrCardsCountOverflow) {
+				// Too many cards for an int
+				part2Big, err := matched.ComputeTotalCardsCountBigWithRule(DefaultCopyRule)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("Part 2: %s\n", part2Big)
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Part 2: %d\n", part2Score)
+
+			return nil
+		},
+		Stream: func(options *utils.Options) error {
+			return utils.StreamInput(inputs, options, func(lines utils.LineIterator) error {
+				part1Score, part2Score, err := ComputeTotalsStream(lines)
+				if err != nil {
+					return err
+				}
+
+				fmt.Printf("Part 1: %d\n", part1Score)
+				fmt.Printf("Part 2: %d\n", part2Score)
+				return nil
+			})
+		},
+	}.Run(args)
 }
 
 /*
@@ -46,51 +96,145 @@ type Card struct {
 
 type ElfStack []Card
 
-func ConvertInputToListOfCards(input []string) ElfStack {
-	cards := make([]Card, 0)
+// MatchedStack is a stack in increasing ID order along with the match count
+// of each card, so that both parts reuse the same match counts. It is
+// computed from the cards as they are when Match is called.
+type MatchedStack struct {
+	Cards       ElfStack
+	MatchCounts []int
+}
 
-	for i, line := range input {
-		card := Card{}
-		card.ID = CardNumber(i + 1)
+// Match sorts the cards by ID and counts the matches of each of them.
+func (stack ElfStack) Match() MatchedStack {
+	sorted := stack.sortedByID()
 
-		// Remove the "Card X: " part
-		numbers := strings.Split(line, ":")[1]
+	matchCounts := make([]int, len(sorted))
+	for card := range sorted {
+		matchCounts[card] = sorted[card].ComputeMatchCount()
+	}
 
-		// Split by "|" to get winning numbers and my numbers
-		rawWinningNumbers := strings.Split(numbers, "|")[0]
-		rawMyNumbers := strings.Split(numbers, "|")[1]
+	return MatchedStack{Cards: sorted, MatchCounts: matchCounts}
+}
 
-		// Split by " " to get each number
-		for _, rawWinningNumber := range strings.Split(rawWinningNumbers, " ") {
-			// Space at beginning of line, or double space before single digit
-			if rawWinningNumber == " " || rawWinningNumber == "" {
-				continue
-			}
-			rawWinningNumber = strings.TrimSpace(rawWinningNumber)
-			number, err := strconv.Atoi(rawWinningNumber)
-			if err != nil {
-				panic(err)
-			}
-			card.WinningNumbers = append(card.WinningNumbers, CardNumber(number))
+// ConvertInputToListOfCards parses the cards, checking that card IDs are
+// unique and that every card has as many winning and owned numbers as the
+// first one. IDs don't need to be in order or to follow each other, but
+// ComputeTotalsStream needs them in increasing order, which LintInput
+// reports.
+func ConvertInputToListOfCards(input []string) (ElfStack, error) {
+	cards := ElfStack{}
+
+	// seenOnLine maps card IDs to the line they were first seen on
+	seenOnLine := map[CardNumber]int{}
+
+	for i, line := range input {
+		card, err := ParseCard(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
 		}
 
-		for _, rawMyNumber := range strings.Split(rawMyNumbers, " ") {
-			// Space at beginning of line, or double space before single digit
-			if rawMyNumber == " " || rawMyNumber == "" {
-				continue
-			}
-			rawMyNumber = strings.TrimSpace(rawMyNumber)
-			number, err := strconv.Atoi(rawMyNumber)
-			if err != nil {
-				panic(err)
+		if firstLine, ok := seenOnLine[card.ID]; ok {
+			return nil, fmt.Errorf("line %d: duplicate card ID %d (first seen on line %d)", i+1, card.ID, firstLine)
+		}
+		seenOnLine[card.ID] = i + 1
+
+		if len(cards) > 0 {
+			if err := card.checkShape(cards[0]); err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
 			}
-			card.MyNumbers = append(card.MyNumbers, CardNumber(number))
 		}
 
 		cards = append(cards, card)
 	}
 
-	return cards
+	return cards, nil
+}
+
+func ParseCard(line string) (Card, error) {
+	// split "Card X: " prefix from the numbers
+	prefix, numbers, ok := strings.Cut(line, ":")
+	if !ok {
+		return Card{}, fmt.Errorf("expected \"Card <id>: <numbers> | <numbers>\", got %q", line)
+	}
+
+	rawID, ok := strings.CutPrefix(prefix, "Card ")
+	if !ok {
+		return Card{}, fmt.Errorf("expected \"Card <id>: <numbers> | <numbers>\", got %q", line)
+	}
+
+	// IDs are right-aligned, "Card   1:", and start at 1
+	rawID = strings.TrimLeft(rawID, " ")
+	id, err := strconv.Atoi(rawID)
+	if err != nil || id < 1 {
+		return Card{}, fmt.Errorf("invalid card ID %q", rawID)
+	}
+
+	// Split by "|" to get winning numbers and my numbers
+	lists := strings.Split(numbers, "|")
+	if len(lists) != 2 {
+		return Card{}, fmt.Errorf("expected exactly one \"|\", found %d", len(lists)-1)
+	}
+
+	winningNumbers, err := parseCardNumbers(lists[0])
+	if err != nil {
+		return Card{}, err
+	}
+
+	myNumbers, err := parseCardNumbers(lists[1])
+	if err != nil {
+		return Card{}, err
+	}
+
+	return Card{ID: CardNumber(id), WinningNumbers: winningNumbers, MyNumbers: myNumbers}, nil
+}
+
+func parseCardNumbers(text string) ([]CardNumber, error) {
+	numbers := []CardNumber{}
+
+	// Fields skips the double spaces before single digits
+	for _, field := range strings.Fields(text) {
+		number, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", field)
+		}
+		numbers = append(numbers, CardNumber(number))
+	}
+
+	return numbers, nil
+}
+
+// checkShape checks that card has as many winning and owned numbers as first.
+func (card Card) checkShape(first Card) error {
+	if len(card.WinningNumbers) != len(first.WinningNumbers) || len(card.MyNumbers) != len(first.MyNumbers) {
+		return fmt.Errorf("card has %d winning and %d owned numbers, expected %d and %d like the first card",
+			len(card.WinningNumbers), len(card.MyNumbers), len(first.WinningNumbers), len(first.MyNumbers))
+	}
+
+	return nil
+}
+
+// MissingIDs returns the IDs between the smallest and the largest card IDs
+// that no card has, in increasing order.
+func (stack ElfStack) MissingIDs() []CardNumber {
+	missing := []CardNumber{}
+
+	sorted := stack.sortedByID()
+	for i := 1; i < len(sorted); i++ {
+		for id := sorted[i-1].ID + 1; id < sorted[i].ID; id++ {
+			missing = append(missing, id)
+		}
+	}
+
+	return missing
+}
+
+func (stack ElfStack) sortedByID() ElfStack {
+	sorted := slices.Clone(stack)
+	slices.SortStableFunc(sorted, func(a, b Card) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return sorted
 }
 
 /*
@@ -101,31 +245,11 @@ the point value of that card.
 */
 
 func (card Card) ComputePoints() int {
-	score := 0
-
-	for _, myNumber := range card.MyNumbers {
-		for _, winningNumber := range card.WinningNumbers {
-			if myNumber == winningNumber {
-				if score == 0 {
-					score = 1
-				} else {
-					score *= 2
-				}
-			}
-		}
-	}
-
-	return score
+	return card.ComputePointsWithRule(DefaultScoringRule)
 }
 
 func (elfStack ElfStack) ComputeTotalPoints() int {
-	totalPoints := 0
-
-	for _, card := range elfStack {
-		totalPoints += card.ComputePoints()
-	}
-
-	return totalPoints
+	return elfStack.ComputeTotalPointsWithRule(DefaultScoringRule)
 }
 
 /*
@@ -133,13 +257,20 @@ There's no such thing as "points". Instead, scratchcards only cause you to win
 more scratchcards equal to the number of winning numbers you have.
 */
 
+// ComputeTotalCardsCount counts the cards won under the puzzle rule,
+// NextMatchedCards rather than whatever DefaultCopyRule is set to, so that
+// the cascade can't fail. Other rules go through
+// ComputeTotalCardsCountWithRule.
 func (stack ElfStack) ComputeTotalCardsCount() int {
-	cardMatchCount := make([]int, len(stack))
-	cardCount := make([]int, len(stack))
+	won, err := stack.Match().cascade(NextMatchedCards{})
+	if err != nil {
+		// NextMatchedCards only gives positive offsets
+		panic(err)
+	}
 
-	for _, card := range stack {
-		cardMatchCount[card.ID-1] = card.ComputeMatchCount()
-		cardCount[card.ID-1] = 1
+	cardCount := make([]int, len(stack))
+	for card := range cardCount {
+		cardCount[card] = 1
 	}
 
 	totalCardsCount := 0
@@ -147,30 +278,144 @@ func (stack ElfStack) ComputeTotalCardsCount() int {
 	for card, count := range cardCount {
 		totalCardsCount += count
 
-		for i := 0; i < cardMatchCount[card]; i++ {
-			cardNumberToIncrease := card + 1 + i
+		for _, next := range won[card] {
+			cardCount[next] += count
+		}
+	}
 
-			if int(cardNumberToIncrease) >= len(cardMatchCount) {
-				break
+	return totalCardsCount
+}
+
+// cascade returns the positions in matched.Cards of the cards won by each
+// card under rule. Like copies of cards past the end of the stack, copies
+// of missing IDs are not won.
+func (matched MatchedStack) cascade(rule CopyRule) ([][]int, error) {
+	if err := validateCopyRule(rule); err != nil {
+		return nil, err
+	}
+
+	sorted := matched.Cards
+	won := make([][]int, len(sorted))
+
+	// The positions won by every card share one backing array
+	positions := []int{}
+
+	for card := range sorted {
+		start := len(positions)
+
+		for _, offset := range rule.WonOffsets(matched.MatchCounts[card]) {
+			// Cards are counted in ID order, so copies can't go backwards.
+			// Rules without Validate are only checked here.
+			if offset <= 0 {
+				return nil, fmt.Errorf("card %d: copy rule gives offset %d, offsets must be positive", sorted[card].ID, offset)
 			}
 
-			cardCount[cardNumberToIncrease] += count
+			id := sorted[card].ID + CardNumber(offset)
+
+			// Without missing IDs, the card is offset positions away
+			if next := card + offset; next < len(sorted) && sorted[next].ID == id {
+				positions = append(positions, next)
+				continue
+			}
+
+			next, ok := slices.BinarySearchFunc(sorted, id, func(other Card, id CardNumber) int {
+				return cmp.Compare(other.ID, id)
+			})
+			if ok {
+				positions = append(positions, next)
+			}
 		}
+
+		won[card] = positions[start:len(positions):len(positions)]
 	}
 
-	return totalCardsCount
+	return won, nil
 }
 
+// ComputeMatchCount counts the distinct numbers you have that are
+// winning numbers.
 func (card Card) ComputeMatchCount() int {
-	count := 0
+	return countMatches(NewNumberSet(card.WinningNumbers), card.MyNumbers)
+}
+
+// ComputeTotalsStream computes the total points and the total cards count
+// under the default rules in a single pass, only remembering the copies won
+// of the next few cards. Card IDs must increase from one line to the next.
+func ComputeTotalsStream(lines utils.LineIterator) (int, int, error) {
+	return ComputeTotalsStreamWithRules(lines, DefaultScoringRule, DefaultCopyRule)
+}
+
+// ComputeTotalsStreamWithRules is ComputeTotalsStream under the scoring
+// and copy rules given. The copies remembered go as far as the largest
+// offset the copy rule gives. It fails with ErrCardsCountOverflow when a
+// count doesn't fit in an int.
+func ComputeTotalsStreamWithRules(lines utils.LineIterator, scoring ScoringRule, copies CopyRule) (int, int, error) {
+	if err := validateCopyRule(copies); err != nil {
+		return 0, 0, err
+	}
+
+	totalPoints := 0
+	totalCardsCount := 0
+
+	// pendingCopies[i] is the count of copies won of the card with the
+	// i-th next ID
+	pendingCopies := []int{}
+
+	first := Card{}
+	previousID := CardNumber(0)
+
+	for line := 1; lines.Scan(); line++ {
+		card, err := ParseCard(lines.Text())
+		if err != nil {
+			return 0, 0, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if line == 1 {
+			first = card
+		} else {
+			if card.ID <= previousID {
+				return 0, 0, fmt.Errorf("line %d: card ID %d doesn't follow card ID %d", line, card.ID, previousID)
+			}
+
+			if err := card.checkShape(first); err != nil {
+				return 0, 0, fmt.Errorf("line %d: %w", line, err)
+			}
+
+			// Copies won of missing IDs are lost
+			skipped := min(int(card.ID-previousID)-1, len(pendingCopies))
+			pendingCopies = pendingCopies[skipped:]
+		}
+		previousID = card.ID
+
+		matchCount := card.ComputeMatchCount()
+		totalPoints += scoring.Points(matchCount)
+
+		count, ok := 1, true
+		if len(pendingCopies) > 0 {
+			count, ok = addChecked(count, pendingCopies[0])
+			pendingCopies = pendingCopies[1:]
+		}
+		if !ok {
+			return 0, 0, fmt.Errorf("line %d: %w", line, ErrCardsCountOverflow)
+		}
+
+		if totalCardsCount, ok = addChecked(totalCardsCount, count); !ok {
+			return 0, 0, fmt.Errorf("line %d: %w", line, ErrCardsCountOverflow)
+		}
 
-	for _, myNumber := range card.MyNumbers {
-		for _, winningNumber := range card.WinningNumbers {
-			if myNumber == winningNumber {
-				count++
+		for _, offset := range copies.WonOffsets(matchCount) {
+			if offset <= 0 {
+				return 0, 0, fmt.Errorf("line %d: copy rule gives offset %d, offsets must be positive", line, offset)
+			}
+
+			for len(pendingCopies) < offset {
+				pendingCopies = append(pendingCopies, 0)
+			}
+			if pendingCopies[offset-1], ok = addChecked(pendingCopies[offset-1], count); !ok {
+				return 0, 0, fmt.Errorf("line %d: %w", line, ErrCardsCountOverflow)
 			}
 		}
 	}
 
-	return count
+	return totalPoints, totalCardsCount, lines.Err()
 }
@@ -1,8 +1,11 @@
-package main
+package day04
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/angristan/advent-of-code-2023/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -31,11 +34,55 @@ func TestConvertInputToListOfCards(t *testing.T) {
 			MyNumbers: []CardNumber{74, 77, 10, 23, 35, 67, 36, 11}},
 	}
 
-	cards := ConvertInputToListOfCards(input)
+	cards, err := ConvertInputToListOfCards(input)
 
+	assert.NoError(t, err)
 	assert.Equal(t, want, cards)
 }
 
+func TestConvertInputToListOfCardsErrors(t *testing.T) {
+	type test struct {
+		input   []string
+		wantErr string
+	}
+
+	tests := []test{
+		{input: []string{"Card 1 41 48 | 83 86"},
+			wantErr: `line 1: expected "Card <id>: <numbers> | <numbers>", got "Card 1 41 48 | 83 86"`},
+		{input: []string{"Deck 1: 41 48 | 83 86"},
+			wantErr: `line 1: expected "Card <id>: <numbers> | <numbers>", got "Deck 1: 41 48 | 83 86"`},
+		{input: []string{"Card x: 41 48 | 83 86"}, wantErr: `line 1: invalid card ID "x"`},
+		{input: []string{"Card 0: 41 48 | 83 86"}, wantErr: `line 1: invalid card ID "0"`},
+		{input: []string{"Card -1: 41 48 | 83 86"}, wantErr: `line 1: invalid card ID "-1"`},
+		{input: []string{"Card 1: 41 48 83 86"}, wantErr: `line 1: expected exactly one "|", found 0`},
+		{input: []string{"Card 1: 41 48 | 83 | 86"}, wantErr: `line 1: expected exactly one "|", found 2`},
+		{input: []string{"Card 1: 41 4x | 83 86"}, wantErr: `line 1: invalid number "4x"`},
+		{input: []string{"Card 1: 41 48 | 83 86", "Card 2: 41 48 | 83 86", "Card 1: 41 48 | 83 86"},
+			wantErr: "line 3: duplicate card ID 1 (first seen on line 1)"},
+		{input: []string{"Card 1: 41 48 | 83 86", "Card 2: 41 | 83 86 17"},
+			wantErr: "line 2: card has 1 winning and 3 owned numbers, expected 2 and 2 like the first card"},
+	}
+
+	for _, tt := range tests {
+		_, err := ConvertInputToListOfCards(tt.input)
+		assert.EqualError(t, err, tt.wantErr)
+	}
+}
+
+func TestParseCardPaddedID(t *testing.T) {
+	card, err := ParseCard("Card  12: 41 | 41")
+
+	assert.NoError(t, err)
+	assert.Equal(t, CardNumber(12), card.ID)
+}
+
+func TestMissingIDs(t *testing.T) {
+	stack := ElfStack{{ID: 7}, {ID: 2}, {ID: 3}, {ID: 5}}
+
+	assert.Equal(t, []CardNumber{4, 6}, stack.MissingIDs())
+	assert.Equal(t, []CardNumber{}, ElfStack{{ID: 2}, {ID: 1}}.MissingIDs())
+}
+
 func TestComputePoints(t *testing.T) {
 	type testInput struct {
 		card      Card
@@ -116,6 +163,46 @@ func TestComputeMatchCount(t *testing.T) {
 	}
 }
 
+func TestComputeMatchCountDuplicates(t *testing.T) {
+	type testInput struct {
+		card      Card
+		wantCount int
+	}
+
+	input := []testInput{
+		// A number you have twice matches once
+		{card: Card{WinningNumbers: []CardNumber{41, 48},
+			MyNumbers: []CardNumber{41, 41, 48}}, wantCount: 2},
+		// A winning number listed twice matches once
+		{card: Card{WinningNumbers: []CardNumber{41, 41, 48},
+			MyNumbers: []CardNumber{41, 6}}, wantCount: 1},
+		// Numbers outside the bitset
+		{card: Card{WinningNumbers: []CardNumber{-1, 128, 1000},
+			MyNumbers: []CardNumber{1000, -1, 127, 1000}}, wantCount: 2},
+	}
+
+	for _, ti := range input {
+		assert.Equal(t, ti.wantCount, ti.card.ComputeMatchCount())
+	}
+}
+
+func TestMatch(t *testing.T) {
+	stack, err := ConvertInputToListOfCards([]string{
+		"Card 2: 1 2 3 | 1 9 9",
+		"Card 1: 1 2 3 | 1 2 3",
+	})
+	assert.NoError(t, err)
+
+	matched := stack.Match()
+	assert.Equal(t, ElfStack{stack[1], stack[0]}, matched.Cards)
+	assert.Equal(t, []int{3, 1}, matched.MatchCounts)
+
+	// Match counts follow edits made to the cards after parsing
+	stack[0].MyNumbers = []CardNumber{1, 2, 9}
+	assert.Equal(t, []int{3, 2}, stack.Match().MatchCounts)
+	assert.Equal(t, 4+2, stack.ComputeTotalPoints())
+}
+
 func TestComputeTotalCardsCount(t *testing.T) {
 	type testInput struct {
 		elfStack         ElfStack
@@ -143,3 +230,115 @@ func TestComputeTotalCardsCount(t *testing.T) {
 		assert.Equal(t, ti.wantTotalMatches, ti.elfStack.ComputeTotalCardsCount())
 	}
 }
+
+func TestComputeTotalCardsCountIDs(t *testing.T) {
+	type test struct {
+		input          []string
+		wantCardsCount int
+	}
+
+	tests := []test{
+		// The example with IDs starting at 11 and out of order
+		{input: []string{
+			"Card 13:  1 21 53 59 44 | 69 82 63 72 16 21 14  1",
+			"Card 11: 41 48 83 86 17 | 83 86  6 31 17  9 48 53",
+			"Card 12: 13 32 20 16 61 | 61 30 68 82 17 32 24 19",
+			"Card 16: 31 18 13 56 72 | 74 77 10 23 35 67 36 11",
+			"Card 14: 41 92 73 84 69 | 59 84 76 51 58  5 54 83",
+			"Card 15: 87 83 26 28 32 | 88 30 70 12 93 22 82 36",
+		}, wantCardsCount: 30},
+		// Card 1 wins copies of cards 2 to 5, of which only 2 and 5 exist,
+		// and card 2 wins copies of the missing card 3
+		{input: []string{
+			"Card 1: 1 2 3 4 | 1 2 3 4",
+			"Card 2: 1 2 3 4 | 1 9 9 9",
+			"Card 5: 1 2 3 4 | 9 9 9 9",
+			"Card 6: 1 2 3 4 | 9 9 9 9",
+		}, wantCardsCount: 1 + 2 + 2 + 1},
+	}
+
+	for _, tt := range tests {
+		stack, err := ConvertInputToListOfCards(tt.input)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.wantCardsCount, stack.ComputeTotalCardsCount())
+	}
+}
+
+func TestComputeTotalsStreamMissingIDs(t *testing.T) {
+	input := []string{
+		"Card 1: 1 2 3 4 | 1 2 3 4",
+		"Card 2: 1 2 3 4 | 1 9 9 9",
+		"Card 5: 1 2 3 4 | 9 9 9 9",
+		"Card 9: 1 2 3 4 | 9 9 9 9",
+	}
+
+	lines := &utils.GeneratedLines{
+		Count: len(input),
+		Line:  func(i int) string { return input[i] },
+	}
+
+	_, totalCardsCount, err := ComputeTotalsStream(lines)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1+2+2+1, totalCardsCount)
+}
+
+func TestComputeTotalsStream(t *testing.T) {
+	input := []string{
+		"Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53",
+		"Card 2: 13 32 20 16 61 | 61 30 68 82 17 32 24 19",
+		"Card 3:  1 21 53 59 44 | 69 82 63 72 16 21 14  1",
+		"Card 4: 41 92 73 84 69 | 59 84 76 51 58  5 54 83",
+		"Card 5: 87 83 26 28 32 | 88 30 70 12 93 22 82 36",
+		"Card 6: 31 18 13 56 72 | 74 77 10 23 35 67 36 11",
+	}
+
+	liveHeapAtEnd := uint64(0)
+	liveHeapBefore := utils.LiveHeap()
+
+	// Cards 5 and 6 win nothing, so every repetition of the example
+	// starts from scratch and scores the same as the example alone.
+	lines := &utils.GeneratedLines{
+		Count: len(input) * 100000,
+		Line: func(i int) string {
+			_, numbers, _ := strings.Cut(input[i%len(input)], ":")
+			return fmt.Sprintf("Card %d:%s", i+1, numbers)
+		},
+		AtEnd: func() { liveHeapAtEnd = utils.LiveHeap() },
+	}
+
+	totalPoints, totalCardsCount, err := ComputeTotalsStream(lines)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 13*100000, totalPoints)
+	assert.Equal(t, 30*100000, totalCardsCount)
+	assert.Less(t, int64(liveHeapAtEnd)-int64(liveHeapBefore), int64(64*1024))
+}
+
+func TestComputeTotalsStreamErrors(t *testing.T) {
+	type test struct {
+		input   []string
+		wantErr string
+	}
+
+	tests := []test{
+		{input: []string{"Card 2: 41 48 | 83 86", "Card 1: 41 48 | 83 86"},
+			wantErr: "line 2: card ID 1 doesn't follow card ID 2"},
+		{input: []string{"Card 1: 41 48 | 83 86", "Card 2: 41 48 | 83"},
+			wantErr: "line 2: card has 2 winning and 1 owned numbers, expected 2 and 2 like the first card"},
+		{input: []string{"Card 1: 41 48 | 83 86", "Card 2 41 48 | 83"},
+			wantErr: `line 2: expected "Card <id>: <numbers> | <numbers>", got "Card 2 41 48 | 83"`},
+		{input: []string{"Card 0: 41 48 | 83 86", "Card 1: 41 48 | 83 86"},
+			wantErr: `line 1: invalid card ID "0"`},
+	}
+
+	for _, tt := range tests {
+		lines := &utils.GeneratedLines{
+			Count: len(tt.input),
+			Line:  func(i int) string { return tt.input[i] },
+		}
+
+		_, _, err := ComputeTotalsStream(lines)
+		assert.EqualError(t, err, tt.wantErr)
+	}
+}
@@ -0,0 +1,44 @@
+package day04
+
+import (
+	"testing"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+)
+
+// readPuzzleCards returns the cards of the embedded puzzle input
+func readPuzzleCards(tb testing.TB) ElfStack {
+	input, err := utils.ReadInput(inputs, &utils.Options{})
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	cards, err := ConvertInputToListOfCards(input)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	return cards
+}
+
+func BenchmarkComputeTotals(b *testing.B) {
+	cards := readPuzzleCards(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		matched := cards.Match()
+		matched.ComputeTotalPointsWithRule(DefaultScoringRule)
+		matched.ComputeTotalCardsCountWithRule(DefaultCopyRule)
+	}
+}
+
+func BenchmarkComputeMatchCount(b *testing.B) {
+	cards := readPuzzleCards(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, card := range cards {
+			card.ComputeMatchCount()
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package day04
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+/*
+Each card wins copies of the next cards, and each copy wins again, so the
+count of copies can double with every card: a stack of 64 cards each
+matching all the cards after it already holds 2^64 - 1 cards.
+
+ComputeTotalCardsCount silently wraps around in that case. The checked
+variant reports the overflow instead, and the big variant never overflows.
+*/
+
+var ErrCardsCountOverflow = errors.New("cards count overflows int")
+
+// ComputeTotalCardsCountChecked is ComputeTotalCardsCount, failing with
+// ErrCardsCountOverflow when a count doesn't fit in an int.
+func (stack ElfStack) ComputeTotalCardsCountChecked() (int, error) {
+	return stack.ComputeTotalCardsCountWithRule(DefaultCopyRule)
+}
+
+// ComputeTotalCardsCountWithRule counts the cards won under rule, failing
+// with ErrCardsCountOverflow when a count doesn't fit in an int.
+func (stack ElfStack) ComputeTotalCardsCountWithRule(rule CopyRule) (int, error) {
+	return stack.Match().ComputeTotalCardsCountWithRule(rule)
+}
+
+func (matched MatchedStack) ComputeTotalCardsCountWithRule(rule CopyRule) (int, error) {
+	won, err := matched.cascade(rule)
+	if err != nil {
+		return 0, err
+	}
+
+	cardCount := make([]int, len(matched.Cards))
+	for card := range cardCount {
+		cardCount[card] = 1
+	}
+
+	totalCardsCount := 0
+
+	for card, count := range cardCount {
+		var ok bool
+		if totalCardsCount, ok = addChecked(totalCardsCount, count); !ok {
+			return 0, fmt.Errorf("card %d: %w", matched.Cards[card].ID, ErrCardsCountOverflow)
+		}
+
+		for _, next := range won[card] {
+			if cardCount[next], ok = addChecked(cardCount[next], count); !ok {
+				return 0, fmt.Errorf("card %d: %w", matched.Cards[next].ID, ErrCardsCountOverflow)
+			}
+		}
+	}
+
+	return totalCardsCount, nil
+}
+
+// ComputeTotalCardsCountBig is ComputeTotalCardsCount with arbitrary
+// precision counts. Like ComputeTotalCardsCount, it uses the puzzle rule,
+// which can't fail.
+func (stack ElfStack) ComputeTotalCardsCountBig() *big.Int {
+	totalCardsCount, err := stack.ComputeTotalCardsCountBigWithRule(NextMatchedCards{})
+	if err != nil {
+		// NextMatchedCards only gives positive offsets
+		panic(err)
+	}
+
+	return totalCardsCount
+}
+
+func (stack ElfStack) ComputeTotalCardsCountBigWithRule(rule CopyRule) (*big.Int, error) {
+	return stack.Match().ComputeTotalCardsCountBigWithRule(rule)
+}
+
+func (matched MatchedStack) ComputeTotalCardsCountBigWithRule(rule CopyRule) (*big.Int, error) {
+	won, err := matched.cascade(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	cardCount := make([]*big.Int, len(matched.Cards))
+	for card := range cardCount {
+		cardCount[card] = big.NewInt(1)
+	}
+
+	totalCardsCount := new(big.Int)
+
+	for card, count := range cardCount {
+		totalCardsCount.Add(totalCardsCount, count)
+
+		for _, next := range won[card] {
+			cardCount[next].Add(cardCount[next], count)
+		}
+	}
+
+	return totalCardsCount, nil
+}
+
+// addChecked adds two non-negative counts, reporting whether the sum fits.
+func addChecked(a, b int) (int, bool) {
+	if b > math.MaxInt-a {
+		return 0, false
+	}
+
+	return a + b, true
+}
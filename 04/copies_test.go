@@ -0,0 +1,97 @@
+package day04
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeTotalCardsCountVariants(t *testing.T) {
+	stack, err := ConvertInputToListOfCards([]string{
+		"Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53",
+		"Card 2: 13 32 20 16 61 | 61 30 68 82 17 32 24 19",
+		"Card 3:  1 21 53 59 44 | 69 82 63 72 16 21 14  1",
+		"Card 4: 41 92 73 84 69 | 59 84 76 51 58  5 54 83",
+		"Card 5: 87 83 26 28 32 | 88 30 70 12 93 22 82 36",
+		"Card 6: 31 18 13 56 72 | 74 77 10 23 35 67 36 11",
+	})
+	assert.NoError(t, err)
+
+	checked, err := stack.ComputeTotalCardsCountChecked()
+	assert.NoError(t, err)
+	assert.Equal(t, 30, checked)
+
+	assert.Equal(t, big.NewInt(30), stack.ComputeTotalCardsCountBig())
+}
+
+func TestComputeTotalCardsCountOverflow(t *testing.T) {
+	type test struct {
+		count     int
+		wantTotal *big.Int
+		wantFits  bool
+	}
+
+	twoToThe := func(n uint) *big.Int {
+		return new(big.Int).Lsh(big.NewInt(1), n)
+	}
+	minusOne := func(n *big.Int) *big.Int {
+		return n.Sub(n, big.NewInt(1))
+	}
+
+	tests := []test{
+		{count: 62, wantTotal: minusOne(twoToThe(62)), wantFits: true},
+		// 2^63 - 1 is exactly math.MaxInt64
+		{count: 63, wantTotal: minusOne(twoToThe(63)), wantFits: true},
+		{count: 64, wantTotal: minusOne(twoToThe(64)), wantFits: false},
+		{count: 100, wantTotal: minusOne(twoToThe(100)), wantFits: false},
+	}
+
+	for _, tt := range tests {
+		// Every card has a match and wins a copy of all the cards after
+		// it, so the card at position i ends up with 2^i copies
+		stack := ElfStack{}
+		for id := 1; id <= tt.count; id++ {
+			stack = append(stack, Card{ID: CardNumber(id), WinningNumbers: []CardNumber{7}, MyNumbers: []CardNumber{7}})
+		}
+		rule := NextCards{N: tt.count}
+
+		total, err := stack.ComputeTotalCardsCountBigWithRule(rule)
+		assert.NoError(t, err, "count %d", tt.count)
+		assert.Equal(t, tt.wantTotal, total, "count %d", tt.count)
+
+		checked, err := stack.ComputeTotalCardsCountWithRule(rule)
+		if tt.wantFits {
+			assert.NoError(t, err, "count %d", tt.count)
+			assert.Equal(t, tt.wantTotal.Int64(), int64(checked), "count %d", tt.count)
+		} else {
+			assert.ErrorIs(t, err, ErrCardsCountOverflow, "count %d", tt.count)
+		}
+
+		// Streaming the same cards fails the same way
+		lines := &utils.GeneratedLines{
+			Count: tt.count,
+			Line:  func(i int) string { return fmt.Sprintf("Card %d: 7 | 7", i+1) },
+		}
+
+		_, streamed, err := ComputeTotalsStreamWithRules(lines, DefaultScoringRule, rule)
+		if tt.wantFits {
+			assert.NoError(t, err, "count %d", tt.count)
+			assert.Equal(t, checked, streamed, "count %d", tt.count)
+		} else {
+			assert.ErrorIs(t, err, ErrCardsCountOverflow, "count %d", tt.count)
+		}
+	}
+}
+
+func TestAddChecked(t *testing.T) {
+	sum, ok := addChecked(math.MaxInt-1, 1)
+	assert.True(t, ok)
+	assert.Equal(t, math.MaxInt, sum)
+
+	_, ok = addChecked(math.MaxInt, 1)
+	assert.False(t, ok)
+}
@@ -0,0 +1,90 @@
+package day04
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+)
+
+var cardLineRegex = regexp.MustCompile(`^Card +(\d+):(.*)$`)
+
+func LintInput(input []string) []utils.LintProblem {
+	problems := []utils.LintProblem{}
+
+	if len(input) == 0 {
+		return append(problems, utils.Lintf(0, "input is empty"))
+	}
+
+	winningCount, myCount := -1, -1
+
+	// seenOnLine maps card IDs to the line they were first seen on
+	seenOnLine := map[int]int{}
+	previousID := 0
+
+	for i, line := range input {
+		matches := cardLineRegex.FindStringSubmatch(line)
+		if matches == nil {
+			problems = append(problems, utils.Lintf(i+1, "expected \"Card <id>: <numbers> | <numbers>\", got %q", line))
+			continue
+		}
+
+		id, _ := strconv.Atoi(matches[1])
+		if id == 0 {
+			problems = append(problems, utils.Lintf(i+1, "invalid card ID %q", matches[1]))
+		} else if firstLine, ok := seenOnLine[id]; ok {
+			problems = append(problems, utils.Lintf(i+1, "duplicate card ID %d (first seen on line %d)", id, firstLine))
+		} else {
+			// Parsing accepts any order, streaming doesn't
+			if id < previousID {
+				problems = append(problems, utils.Lintf(i+1, "card ID %d comes after card ID %d, the stream subcommand needs increasing IDs", id, previousID))
+			}
+			seenOnLine[id] = i + 1
+			previousID = id
+		}
+
+		lists := strings.Split(matches[2], "|")
+		if len(lists) != 2 {
+			problems = append(problems, utils.Lintf(i+1, "expected exactly one \"|\", found %d", len(lists)-1))
+			continue
+		}
+
+		winningNumbers := strings.Fields(lists[0])
+		myNumbers := strings.Fields(lists[1])
+
+		for _, number := range append(winningNumbers, myNumbers...) {
+			if _, err := strconv.Atoi(number); err != nil {
+				problems = append(problems, utils.Lintf(i+1, "invalid number %q", number))
+			}
+		}
+
+		if winningCount == -1 {
+			winningCount, myCount = len(winningNumbers), len(myNumbers)
+			continue
+		}
+
+		if len(winningNumbers) != winningCount || len(myNumbers) != myCount {
+			problems = append(problems, utils.Lintf(i+1, "card has %d winning and %d owned numbers, expected %d and %d like the first card",
+				len(winningNumbers), len(myNumbers), winningCount, myCount))
+		}
+	}
+
+	// Copies won of missing cards are lost
+	ids := make([]int, 0, len(seenOnLine))
+	for id := range seenOnLine {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	for i := 1; i < len(ids); i++ {
+		if ids[i] == ids[i-1]+2 {
+			problems = append(problems, utils.Lintf(0, "card ID %d is missing", ids[i]-1))
+		} else if ids[i] > ids[i-1]+2 {
+			problems = append(problems, utils.Lintf(0, "card IDs %d to %d are missing", ids[i-1]+1, ids[i]-1))
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,50 @@
+package day04
+
+import (
+	"testing"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintInput(t *testing.T) {
+	input := []string{
+		"Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53",
+		"Card 2: 13 32 20 16 61   61 30 68 82 17 32 24 19",
+		"Card 3:  1 21 53 59 | 69 82 63 72 16 21 14  1",
+		"Card 5: 41 92 73 84 69 | 59 84 76 51 58  5 54 8x",
+		"Card 3: 41 92 73 84 69 | 59 84 76 51 58  5 54 83",
+		"Card 9: 41 92 73 84 69 | 59 84 76 51 58  5 54 83",
+	}
+
+	want := []utils.LintProblem{
+		{Line: 2, Message: "expected exactly one \"|\", found 0"},
+		{Line: 3, Message: "card has 4 winning and 8 owned numbers, expected 5 and 8 like the first card"},
+		{Line: 4, Message: "invalid number \"8x\""},
+		{Line: 5, Message: "duplicate card ID 3 (first seen on line 3)"},
+		{Line: 0, Message: "card ID 4 is missing"},
+		{Line: 0, Message: "card IDs 6 to 8 are missing"},
+	}
+
+	assert.Equal(t, want, LintInput(input))
+}
+
+func TestLintInputIDs(t *testing.T) {
+	type test struct {
+		input []string
+		want  []utils.LintProblem
+	}
+
+	tests := []test{
+		{input: []string{"Card 0: 41 | 83", "Card 1: 41 | 83"},
+			want: []utils.LintProblem{{Line: 1, Message: `invalid card ID "0"`}}},
+		{input: []string{"Card 2: 41 | 83", "Card 1: 41 | 83", "Card 3: 41 | 83"},
+			want: []utils.LintProblem{{Line: 2, Message: "card ID 1 comes after card ID 2, the stream subcommand needs increasing IDs"}}},
+		{input: []string{"Card 1: 41 | 83", "Card 2: 41 | 83", "Card 3: 41 | 83"},
+			want: []utils.LintProblem{}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, LintInput(tt.input))
+	}
+}
@@ -0,0 +1,72 @@
+package day04
+
+// smallNumbers is the count of card numbers kept in the bitset of a
+// NumberSet, which covers the two-digit numbers of actual cards.
+const smallNumbers = 128
+
+// NumberSet is a set of card numbers. Numbers in [0, smallNumbers) are bits,
+// any other number goes to a map only allocated when needed.
+type NumberSet struct {
+	small [smallNumbers / 64]uint64
+	large map[CardNumber]bool
+}
+
+func NewNumberSet(numbers []CardNumber) NumberSet {
+	set := NumberSet{}
+	for _, number := range numbers {
+		set.Add(number)
+	}
+
+	return set
+}
+
+func (set *NumberSet) Add(number CardNumber) {
+	if bit := uint(number); bit < smallNumbers {
+		set.small[bit/64] |= 1 << (bit % 64)
+		return
+	}
+
+	if set.large == nil {
+		set.large = map[CardNumber]bool{}
+	}
+	set.large[number] = true
+}
+
+func (set NumberSet) Contains(number CardNumber) bool {
+	if bit := uint(number); bit < smallNumbers {
+		return set.small[bit/64]&(1<<(bit%64)) != 0
+	}
+
+	return set.large[number]
+}
+
+// countMatches counts the distinct numbers of myNumbers that are in winning.
+// A number you have twice only matches once, and a winning number listed
+// twice doesn't make it match twice.
+func countMatches(winning NumberSet, myNumbers []CardNumber) int {
+	// Winning numbers are taken off a copy of the set once matched
+	remaining := winning.small
+	matchedLarge := map[CardNumber]bool(nil)
+	count := 0
+
+	for _, number := range myNumbers {
+		if bit := uint(number); bit < smallNumbers {
+			mask := uint64(1) << (bit % 64)
+			if remaining[bit/64]&mask != 0 {
+				remaining[bit/64] &^= mask
+				count++
+			}
+			continue
+		}
+
+		if winning.large[number] && !matchedLarge[number] {
+			if matchedLarge == nil {
+				matchedLarge = map[CardNumber]bool{}
+			}
+			matchedLarge[number] = true
+			count++
+		}
+	}
+
+	return count
+}
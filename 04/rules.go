@@ -0,0 +1,204 @@
+package day04
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+/*
+The puzzle scores a card 1 point for its first match and doubles the points
+for each further match, and makes it win copies of as many next cards as
+it has matches. Other rules can be plugged in to see how the stack fares.
+*/
+
+// ScoringRule gives the points of a card with matchCount matches.
+type ScoringRule interface {
+	Points(matchCount int) int
+}
+
+// CopyRule gives the offsets from the ID of a card with matchCount matches
+// to the IDs of the cards it wins a copy of, one copy per offset.
+// Offsets must be positive. Rules with parameters can also implement
+// Validate, so that invalid parameters are reported before counting.
+type CopyRule interface {
+	WonOffsets(matchCount int) []int
+}
+
+// validateCopyRule checks the parameters of rule, if it can.
+func validateCopyRule(rule CopyRule) error {
+	if validator, ok := rule.(interface{ Validate() error }); ok {
+		return validator.Validate()
+	}
+
+	return nil
+}
+
+var (
+	DefaultScoringRule ScoringRule = DoublingScoring{}
+	DefaultCopyRule    CopyRule    = NextMatchedCards{}
+)
+
+// DoublingScoring gives 1 point for the first match and doubles
+// the points for each further match: 0, 1, 2, 4, 8... Points that don't
+// fit in an int are capped to math.MaxInt.
+type DoublingScoring struct{}
+
+func (DoublingScoring) Points(matchCount int) int {
+	if matchCount == 0 {
+		return 0
+	}
+
+	// The sign bit is out of reach
+	if matchCount >= bits.UintSize {
+		return math.MaxInt
+	}
+
+	return 1 << (matchCount - 1)
+}
+
+// LinearScoring gives PointsPerMatch points for each match.
+type LinearScoring struct {
+	PointsPerMatch int
+}
+
+func (rule LinearScoring) Points(matchCount int) int {
+	return rule.PointsPerMatch * matchCount
+}
+
+// FibonacciScoring gives 1 point for the first match, 2 for the second, and
+// then the sum of the previous two: 0, 1, 2, 3, 5, 8...
+type FibonacciScoring struct{}
+
+func (FibonacciScoring) Points(matchCount int) int {
+	if matchCount == 0 {
+		return 0
+	}
+
+	previous, points := 1, 1
+	for i := 1; i < matchCount; i++ {
+		previous, points = points, previous+points
+	}
+
+	return points
+}
+
+// TableScoring gives table[matchCount] points, and the points of the last
+// entry to cards with more matches than the table has entries.
+type TableScoring []int
+
+func (table TableScoring) Points(matchCount int) int {
+	if len(table) == 0 {
+		return 0
+	}
+
+	return table[min(matchCount, len(table)-1)]
+}
+
+// NextMatchedCards wins a copy of as many next cards as the card has matches.
+type NextMatchedCards struct{}
+
+func (NextMatchedCards) WonOffsets(matchCount int) []int {
+	offsets := make([]int, 0, matchCount)
+	for offset := 1; offset <= matchCount; offset++ {
+		offsets = append(offsets, offset)
+	}
+
+	return offsets
+}
+
+// NextCards wins a copy of the next N cards when the card has any match.
+type NextCards struct {
+	N int
+}
+
+func (rule NextCards) WonOffsets(matchCount int) []int {
+	if matchCount == 0 || rule.N <= 0 {
+		return nil
+	}
+
+	return NextMatchedCards{}.WonOffsets(rule.N)
+}
+
+func (rule NextCards) Validate() error {
+	if rule.N < 0 {
+		return fmt.Errorf("next cards rule wins %d cards, expected at least 0", rule.N)
+	}
+
+	return nil
+}
+
+// OffsetCards wins a copy of the card at Offsets[i] from the card for the
+// (i+1)-th match. Matches beyond the offsets win nothing.
+type OffsetCards struct {
+	Offsets []int
+}
+
+func (rule OffsetCards) WonOffsets(matchCount int) []int {
+	return rule.Offsets[:min(matchCount, len(rule.Offsets))]
+}
+
+func (rule OffsetCards) Validate() error {
+	for i, offset := range rule.Offsets {
+		if offset <= 0 {
+			return fmt.Errorf("offset cards rule has offset %d for match %d, offsets must be positive", offset, i+1)
+		}
+	}
+
+	return nil
+}
+
+func (card Card) ComputePointsWithRule(rule ScoringRule) int {
+	return rule.Points(card.ComputeMatchCount())
+}
+
+func (elfStack ElfStack) ComputeTotalPointsWithRule(rule ScoringRule) int {
+	return elfStack.Match().ComputeTotalPointsWithRule(rule)
+}
+
+func (matched MatchedStack) ComputeTotalPointsWithRule(rule ScoringRule) int {
+	totalPoints := 0
+
+	for _, matchCount := range matched.MatchCounts {
+		totalPoints += rule.Points(matchCount)
+	}
+
+	return totalPoints
+}
+
+// RuleVariant is a named pair of rules to evaluate a stack with.
+type RuleVariant struct {
+	Name    string
+	Scoring ScoringRule
+	Copies  CopyRule
+}
+
+// VariantResult is the total points and the total cards count of a stack
+// under a RuleVariant.
+type VariantResult struct {
+	Variant         RuleVariant
+	TotalPoints     int
+	TotalCardsCount int
+}
+
+// EvaluateVariants evaluates the stack under each variant, in order.
+// Cards are only matched once for all the variants.
+func (elfStack ElfStack) EvaluateVariants(variants []RuleVariant) ([]VariantResult, error) {
+	matched := elfStack.Match()
+	results := make([]VariantResult, 0, len(variants))
+
+	for _, variant := range variants {
+		totalCardsCount, err := matched.ComputeTotalCardsCountWithRule(variant.Copies)
+		if err != nil {
+			return nil, fmt.Errorf("variant %s: %w", variant.Name, err)
+		}
+
+		results = append(results, VariantResult{
+			Variant:         variant,
+			TotalPoints:     matched.ComputeTotalPointsWithRule(variant.Scoring),
+			TotalCardsCount: totalCardsCount,
+		})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,185 @@
+package day04
+
+import (
+	"math"
+	"testing"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScoringRules(t *testing.T) {
+	type test struct {
+		rule       ScoringRule
+		wantPoints []int
+	}
+
+	tests := []test{
+		{rule: DoublingScoring{}, wantPoints: []int{0, 1, 2, 4, 8, 16}},
+		{rule: LinearScoring{PointsPerMatch: 3}, wantPoints: []int{0, 3, 6, 9, 12, 15}},
+		{rule: FibonacciScoring{}, wantPoints: []int{0, 1, 2, 3, 5, 8}},
+		{rule: TableScoring{0, 10, 15}, wantPoints: []int{0, 10, 15, 15, 15, 15}},
+		{rule: TableScoring{}, wantPoints: []int{0, 0, 0, 0, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		for matchCount, wantPoints := range tt.wantPoints {
+			assert.Equal(t, wantPoints, tt.rule.Points(matchCount), "%#v with %d matches", tt.rule, matchCount)
+		}
+	}
+}
+
+func TestDoublingScoringCap(t *testing.T) {
+	type test struct {
+		matchCount int
+		wantPoints int
+	}
+
+	tests := []test{
+		{matchCount: 63, wantPoints: 1 << 62},
+		{matchCount: 64, wantPoints: math.MaxInt},
+		{matchCount: 1000, wantPoints: math.MaxInt},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.wantPoints, DoublingScoring{}.Points(tt.matchCount), "%d matches", tt.matchCount)
+	}
+}
+
+func TestCopyRules(t *testing.T) {
+	type test struct {
+		rule        CopyRule
+		matchCount  int
+		wantOffsets []int
+	}
+
+	tests := []test{
+		{rule: NextMatchedCards{}, matchCount: 0, wantOffsets: []int{}},
+		{rule: NextMatchedCards{}, matchCount: 3, wantOffsets: []int{1, 2, 3}},
+		{rule: NextCards{N: 2}, matchCount: 0, wantOffsets: nil},
+		{rule: NextCards{N: 2}, matchCount: 4, wantOffsets: []int{1, 2}},
+		{rule: OffsetCards{Offsets: []int{2, 4}}, matchCount: 1, wantOffsets: []int{2}},
+		{rule: OffsetCards{Offsets: []int{2, 4}}, matchCount: 3, wantOffsets: []int{2, 4}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.wantOffsets, tt.rule.WonOffsets(tt.matchCount), "%#v with %d matches", tt.rule, tt.matchCount)
+	}
+}
+
+func TestEvaluateVariants(t *testing.T) {
+	// Match counts are 4, 2, 2, 1, 0, 0
+	stack, err := ConvertInputToListOfCards([]string{
+		"Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53",
+		"Card 2: 13 32 20 16 61 | 61 30 68 82 17 32 24 19",
+		"Card 3:  1 21 53 59 44 | 69 82 63 72 16 21 14  1",
+		"Card 4: 41 92 73 84 69 | 59 84 76 51 58  5 54 83",
+		"Card 5: 87 83 26 28 32 | 88 30 70 12 93 22 82 36",
+		"Card 6: 31 18 13 56 72 | 74 77 10 23 35 67 36 11",
+	})
+	assert.NoError(t, err)
+
+	variants := []RuleVariant{
+		{Name: "puzzle", Scoring: DefaultScoringRule, Copies: DefaultCopyRule},
+		// Cards 1 to 4 win a copy of the next card: 1, 2, 3, 4, 5, 1
+		{Name: "next", Scoring: LinearScoring{PointsPerMatch: 1}, Copies: NextCards{N: 1}},
+		// Cards 1, 2 and 3 win 2 copies of the card after next, card 1 also
+		// wins card 5 and card 4 wins card 6: 1, 1, 3, 3, 8, 4
+		{Name: "offsets", Scoring: FibonacciScoring{}, Copies: OffsetCards{Offsets: []int{2, 2, 4}}},
+	}
+
+	want := []VariantResult{
+		{Variant: variants[0], TotalPoints: 13, TotalCardsCount: 30},
+		{Variant: variants[1], TotalPoints: 9, TotalCardsCount: 16},
+		{Variant: variants[2], TotalPoints: 5 + 2 + 2 + 1, TotalCardsCount: 20},
+	}
+
+	results, err := stack.EvaluateVariants(variants)
+
+	assert.NoError(t, err)
+	assert.Equal(t, want, results)
+}
+
+// backwardsCopies is a copy rule without Validate, going back one card
+type backwardsCopies struct{}
+
+func (backwardsCopies) WonOffsets(matchCount int) []int {
+	return []int{-1}
+}
+
+func TestEvaluateVariantsInvalidRules(t *testing.T) {
+	stack := ElfStack{{ID: 1, WinningNumbers: []CardNumber{1}, MyNumbers: []CardNumber{1}}}
+
+	type test struct {
+		copies  CopyRule
+		wantErr string
+	}
+
+	tests := []test{
+		{copies: OffsetCards{Offsets: []int{-1}},
+			wantErr: "variant invalid: offset cards rule has offset -1 for match 1, offsets must be positive"},
+		// The card only has 1 match, the second offset is rejected up front
+		{copies: OffsetCards{Offsets: []int{2, 0}},
+			wantErr: "variant invalid: offset cards rule has offset 0 for match 2, offsets must be positive"},
+		{copies: NextCards{N: -1},
+			wantErr: "variant invalid: next cards rule wins -1 cards, expected at least 0"},
+		{copies: backwardsCopies{},
+			wantErr: "variant invalid: card 1: copy rule gives offset -1, offsets must be positive"},
+	}
+
+	for _, tt := range tests {
+		_, err := stack.EvaluateVariants([]RuleVariant{
+			{Name: "invalid", Scoring: DoublingScoring{}, Copies: tt.copies},
+		})
+		assert.EqualError(t, err, tt.wantErr)
+	}
+
+	assert.Nil(t, NextCards{N: -1}.WonOffsets(1))
+}
+
+func TestComputeTotalsStreamWithRules(t *testing.T) {
+	input := []string{
+		"Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53",
+		"Card 2: 13 32 20 16 61 | 61 30 68 82 17 32 24 19",
+		"Card 3:  1 21 53 59 44 | 69 82 63 72 16 21 14  1",
+		"Card 4: 41 92 73 84 69 | 59 84 76 51 58  5 54 83",
+		"Card 5: 87 83 26 28 32 | 88 30 70 12 93 22 82 36",
+		"Card 6: 31 18 13 56 72 | 74 77 10 23 35 67 36 11",
+	}
+
+	stack, err := ConvertInputToListOfCards(input)
+	assert.NoError(t, err)
+
+	variants := []RuleVariant{
+		{Name: "puzzle", Scoring: DefaultScoringRule, Copies: DefaultCopyRule},
+		{Name: "next", Scoring: LinearScoring{PointsPerMatch: 1}, Copies: NextCards{N: 1}},
+		{Name: "offsets", Scoring: FibonacciScoring{}, Copies: OffsetCards{Offsets: []int{2, 2, 4}}},
+	}
+
+	results, err := stack.EvaluateVariants(variants)
+	assert.NoError(t, err)
+
+	// Streaming agrees with the whole stack under every variant
+	for _, result := range results {
+		lines := &utils.GeneratedLines{
+			Count: len(input),
+			Line:  func(i int) string { return input[i] },
+		}
+
+		totalPoints, totalCardsCount, err := ComputeTotalsStreamWithRules(lines, result.Variant.Scoring, result.Variant.Copies)
+
+		assert.NoError(t, err)
+		assert.Equal(t, result.TotalPoints, totalPoints, result.Variant.Name)
+		assert.Equal(t, result.TotalCardsCount, totalCardsCount, result.Variant.Name)
+	}
+
+	for _, copies := range []CopyRule{NextCards{N: -1}, backwardsCopies{}} {
+		lines := &utils.GeneratedLines{
+			Count: len(input),
+			Line:  func(i int) string { return input[i] },
+		}
+
+		_, _, err := ComputeTotalsStreamWithRules(lines, DefaultScoringRule, copies)
+		assert.Error(t, err)
+	}
+}
@@ -1,7 +1,9 @@
-package main
+package day05
 
 import (
+	"embed"
 	"fmt"
+	"os"
 	"regexp"
 	"slices"
 	"strconv"
@@ -10,16 +12,39 @@ import (
 	"github.com/angristan/advent-of-code-2023/utils"
 )
 
-func main() {
-	input := utils.ParseInput("input.txt")
-
-	almanacV1 := ConvertInputToAlmanac(input)
-	part1Score := almanacV1.GetLowestLocationNumber()
-	fmt.Printf("Part 1: %d\n", part1Score)
-
-	almanacV2 := ConvertInputToAlmanacV2(input)
-	part2score := almanacV2.GetLowestLocationNumber()
-	fmt.Printf("Part 2: %d\n", part2score)
+//go:embed input.txt example*.txt
+var inputs embed.FS
+
+// Run prints the lowest location of the seeds, then of the seed ranges,
+// or explores the almanac with the repl subcommand.
+func Run(args []string) error {
+	config := utils.NoConfig{}
+
+	return utils.DayRunner{
+		Day:    "05",
+		Inputs: inputs,
+		Config: &config,
+		Lint:   LintInput,
+		Solve: func(input []string) error {
+			almanacV1 := ConvertInputToAlmanac(input)
+			part1Score := almanacV1.GetLowestLocationNumber()
+			fmt.Printf("Part 1: %d\n", part1Score)
+
+			almanacV2 := ConvertInputToAlmanacV2(input)
+			part2score := almanacV2.GetLowestLocationNumber()
+			fmt.Printf("Part 2: %d\n", part2score)
+
+			return nil
+		},
+		Subcommands: map[string]utils.Subcommand{
+			"repl": {
+				Usage: "repl",
+				Run: func(input []string, options *utils.Options) error {
+					return ConvertInputToAlmanac(input).REPL().Run(os.Stdin, os.Stdout)
+				},
+			},
+		},
+	}.Run(args)
 }
 
 type Range struct {
@@ -102,22 +127,37 @@ func (almanac Almanac) GetSeedsLocations() []int {
 	locations := make([]int, 0)
 
 	for _, seed := range almanac.Seeds {
-		nextIndex := int(seed)
-	nextMap:
-		for _, m := range almanac.Maps {
-			for _, r := range m.Ranges {
-				if nextIndex >= r.SourceIndex && nextIndex < r.SourceIndex+r.RangeLength {
-					nextIndex = r.DestinationIndex + int(nextIndex) - r.SourceIndex
-					continue nextMap
-				}
+		locations = append(locations, almanac.GetSeedLocation(seed))
+	}
+
+	return locations
+}
+
+func (almanac Almanac) GetSeedLocation(seed Seed) int {
+	path := almanac.GetSeedPath(seed)
+
+	return path[len(path)-1]
+}
+
+// GetSeedPath returns the seed number followed by the value it maps to
+// after each map, the last one being its location.
+func (almanac Almanac) GetSeedPath(seed Seed) []int {
+	path := []int{int(seed)}
+
+	nextIndex := int(seed)
+nextMap:
+	for _, m := range almanac.Maps {
+		for _, r := range m.Ranges {
+			if nextIndex >= r.SourceIndex && nextIndex < r.SourceIndex+r.RangeLength {
+				nextIndex = r.DestinationIndex + int(nextIndex) - r.SourceIndex
+				path = append(path, nextIndex)
+				continue nextMap
 			}
 		}
-
-		location := nextIndex
-		locations = append(locations, location)
+		path = append(path, nextIndex)
 	}
 
-	return locations
+	return path
 }
 
 func (almanac Almanac) GetLowestLocationNumber() int {
@@ -1,4 +1,4 @@
-package main
+package day05
 
 import (
 	"testing"
@@ -130,6 +130,50 @@ func TestGetSeedsLocations(t *testing.T) {
 	assert.Equal(t, alamanac.GetSeedsLocations(), expectedLocations)
 }
 
+func TestGetSeedPath(t *testing.T) {
+	alamanac := Almanac{
+		Seeds: []Seed{79, 14, 55, 13},
+		Maps: []Map{
+			{Ranges: []Range{
+				{DestinationIndex: 50, SourceIndex: 98, RangeLength: 2},
+				{DestinationIndex: 52, SourceIndex: 50, RangeLength: 48}},
+			},
+			{Ranges: []Range{
+				{DestinationIndex: 0, SourceIndex: 15, RangeLength: 37},
+				{DestinationIndex: 37, SourceIndex: 52, RangeLength: 2},
+				{DestinationIndex: 39, SourceIndex: 0, RangeLength: 15}},
+			},
+			{Ranges: []Range{
+				{DestinationIndex: 49, SourceIndex: 53, RangeLength: 8},
+				{DestinationIndex: 0, SourceIndex: 11, RangeLength: 42},
+				{DestinationIndex: 42, SourceIndex: 0, RangeLength: 7},
+				{DestinationIndex: 57, SourceIndex: 7, RangeLength: 4}},
+			},
+			{Ranges: []Range{
+				{DestinationIndex: 88, SourceIndex: 18, RangeLength: 7},
+				{DestinationIndex: 18, SourceIndex: 25, RangeLength: 70}},
+			},
+			{Ranges: []Range{
+				{DestinationIndex: 45, SourceIndex: 77, RangeLength: 23},
+				{DestinationIndex: 81, SourceIndex: 45, RangeLength: 19},
+				{DestinationIndex: 68, SourceIndex: 64, RangeLength: 13}},
+			},
+			{Ranges: []Range{
+				{DestinationIndex: 0, SourceIndex: 69, RangeLength: 1},
+				{DestinationIndex: 1, SourceIndex: 0, RangeLength: 69}},
+			},
+			{Ranges: []Range{
+				{DestinationIndex: 60, SourceIndex: 56, RangeLength: 37},
+				{DestinationIndex: 56, SourceIndex: 93, RangeLength: 4}},
+			},
+		},
+	}
+
+	// Seed 79, soil 81, fertilizer 81, water 81, light 74, temperature 78, humidity 78, location 82.
+	assert.Equal(t, []int{79, 81, 81, 81, 74, 78, 78, 82}, alamanac.GetSeedPath(79))
+	assert.Equal(t, 82, alamanac.GetSeedLocation(79))
+}
+
 func TestGetLowestLocationNumber(t *testing.T) {
 	alamanac := Almanac{
 		Seeds: []Seed{79, 14, 55, 13},
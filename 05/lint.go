@@ -0,0 +1,71 @@
+package day05
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+)
+
+var (
+	seedsLineRegex = regexp.MustCompile(`^seeds:( \d+)+$`)
+	mapHeaderRegex = regexp.MustCompile(`^([a-z]+)-to-([a-z]+) map:$`)
+	rangeLineRegex = regexp.MustCompile(`^\d+ \d+ \d+$`)
+)
+
+func LintInput(input []string) []utils.LintProblem {
+	problems := []utils.LintProblem{}
+
+	if len(input) < 3 {
+		return append(problems, utils.Lintf(0, "expected seeds and at least one map, got %d lines", len(input)))
+	}
+
+	if !seedsLineRegex.MatchString(input[0]) {
+		problems = append(problems, utils.Lintf(1, "expected \"seeds: <numbers>\", got %q", input[0]))
+	} else if len(numberRegex.FindAllString(input[0], -1))%2 != 0 {
+		problems = append(problems, utils.Lintf(1, "odd count of seed numbers, they can't be read as ranges"))
+	}
+
+	if input[1] != "" {
+		problems = append(problems, utils.Lintf(2, "expected an empty line after seeds, got %q", input[1]))
+	}
+
+	// Maps are applied in order, so each one must start from the
+	// category the previous one ended with.
+	expectedSource := "seed"
+	inMap := false
+	mapsCount := 0
+
+	for i, line := range input[2:] {
+		lineNumber := i + 3
+
+		switch {
+		case line == "":
+			inMap = false
+		case strings.HasSuffix(line, "map:"):
+			matches := mapHeaderRegex.FindStringSubmatch(line)
+			if matches == nil {
+				problems = append(problems, utils.Lintf(lineNumber, "malformed map header %q", line))
+				continue
+			}
+			if matches[1] != expectedSource {
+				problems = append(problems, utils.Lintf(lineNumber, "map starts from %q, expected %q", matches[1], expectedSource))
+			}
+			expectedSource = matches[2]
+			inMap = true
+			mapsCount++
+		case !inMap:
+			problems = append(problems, utils.Lintf(lineNumber, "range %q outside of a map", line))
+		case !rangeLineRegex.MatchString(line):
+			problems = append(problems, utils.Lintf(lineNumber, "expected \"<destination> <source> <length>\", got %q", line))
+		}
+	}
+
+	if mapsCount == 0 {
+		problems = append(problems, utils.Lintf(0, "no map found"))
+	} else if expectedSource != "location" {
+		problems = append(problems, utils.Lintf(0, "last map ends at %q, expected \"location\"", expectedSource))
+	}
+
+	return problems
+}
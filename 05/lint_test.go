@@ -0,0 +1,36 @@
+package day05
+
+import (
+	"testing"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintInput(t *testing.T) {
+	input := []string{
+		"seeds: 79 14 55",
+		"",
+		"seed-to-soil map:",
+		"50 98 2",
+		"52 50",
+		"",
+		"soil-to-fertilizer:map:",
+		"",
+		"water-to-light map:",
+		"88 18 7",
+		"",
+		"18 25 70",
+	}
+
+	want := []utils.LintProblem{
+		{Line: 1, Message: "odd count of seed numbers, they can't be read as ranges"},
+		{Line: 5, Message: "expected \"<destination> <source> <length>\", got \"52 50\""},
+		{Line: 7, Message: "malformed map header \"soil-to-fertilizer:map:\""},
+		{Line: 9, Message: "map starts from \"water\", expected \"soil\""},
+		{Line: 12, Message: "range \"18 25 70\" outside of a map"},
+		{Line: 0, Message: "last map ends at \"light\", expected \"location\""},
+	}
+
+	assert.Equal(t, want, LintInput(input))
+}
@@ -0,0 +1,70 @@
+package day05
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+)
+
+func (almanac Almanac) REPL() utils.REPL {
+	return utils.REPL{
+		Prompt: "day05> ",
+		Commands: map[string]utils.REPLCommand{
+			"location": {
+				Usage: "location <seed>",
+				Run: func(args []string) (string, error) {
+					values, err := utils.IntArgs(args, 1)
+					if err != nil {
+						return "", err
+					}
+
+					return fmt.Sprintf("%d", almanac.GetSeedLocation(Seed(values[0]))), nil
+				},
+			},
+			"path": {
+				Usage: "path <seed>",
+				Run: func(args []string) (string, error) {
+					values, err := utils.IntArgs(args, 1)
+					if err != nil {
+						return "", err
+					}
+
+					steps := []string{}
+					for _, step := range almanac.GetSeedPath(Seed(values[0])) {
+						steps = append(steps, fmt.Sprintf("%d", step))
+					}
+
+					return strings.Join(steps, " -> "), nil
+				},
+			},
+			"seeds": {
+				Usage: "seeds",
+				Run: func(args []string) (string, error) {
+					return fmt.Sprintf("%v", almanac.Seeds), nil
+				},
+			},
+			"map": {
+				Usage: "map <index>",
+				Run: func(args []string) (string, error) {
+					values, err := utils.IntArgs(args, 1)
+					if err != nil {
+						return "", err
+					}
+
+					index := values[0]
+					if index < 0 || index >= len(almanac.Maps) {
+						return "", fmt.Errorf("no map %d, there are %d maps", index, len(almanac.Maps))
+					}
+
+					ranges := []string{}
+					for _, r := range almanac.Maps[index].Ranges {
+						ranges = append(ranges, fmt.Sprintf("%d %d %d", r.DestinationIndex, r.SourceIndex, r.RangeLength))
+					}
+
+					return strings.Join(ranges, "\n"), nil
+				},
+			},
+		},
+	}
+}
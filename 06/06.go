@@ -1,6 +1,7 @@
-package main
+package day06
 
 import (
+	"embed"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -8,16 +9,31 @@ import (
 	"github.com/angristan/advent-of-code-2023/utils"
 )
 
-func main() {
-	input := utils.ParseInput("input.txt")
-
-	parsedInput := ConvertRawInputToInput(input)
-	part1Score := parsedInput.ComputeAllPossibleRecordCount()
-	fmt.Printf("Part 1: %d\n", part1Score)
-
-	parsedInputv2 := ConvertRawInputToInputV2(input)
-	part2Score := parsedInputv2.ComputeAllPossibleRecordCount()
-	fmt.Printf("Part 2: %d\n", part2Score)
+//go:embed input.txt example*.txt
+var inputs embed.FS
+
+// Run prints the product of the ways to win each race, then the ways to
+// win the single long race.
+func Run(args []string) error {
+	config := utils.NoConfig{}
+
+	return utils.DayRunner{
+		Day:    "06",
+		Inputs: inputs,
+		Config: &config,
+		Lint:   LintInput,
+		Solve: func(input []string) error {
+			parsedInput := ConvertRawInputToInput(input)
+			part1Score := parsedInput.ComputeAllPossibleRecordCount()
+			fmt.Printf("Part 1: %d\n", part1Score)
+
+			parsedInputv2 := ConvertRawInputToInputV2(input)
+			part2Score := parsedInputv2.ComputeAllPossibleRecordCount()
+			fmt.Printf("Part 2: %d\n", part2Score)
+
+			return nil
+		},
+	}.Run(args)
 }
 
 type Race struct {
@@ -0,0 +1,36 @@
+package day06
+
+import (
+	"regexp"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+)
+
+var (
+	timeLineRegex     = regexp.MustCompile(`^Time:( +\d+)+$`)
+	distanceLineRegex = regexp.MustCompile(`^Distance:( +\d+)+$`)
+)
+
+func LintInput(rawInput []string) []utils.LintProblem {
+	problems := []utils.LintProblem{}
+
+	if len(rawInput) != 2 {
+		return append(problems, utils.Lintf(0, "expected 2 lines, got %d", len(rawInput)))
+	}
+
+	if !timeLineRegex.MatchString(rawInput[0]) {
+		problems = append(problems, utils.Lintf(1, "expected \"Time: <numbers>\", got %q", rawInput[0]))
+	}
+
+	if !distanceLineRegex.MatchString(rawInput[1]) {
+		problems = append(problems, utils.Lintf(2, "expected \"Distance: <numbers>\", got %q", rawInput[1]))
+	}
+
+	durationsCount := len(numberRegex.FindAllString(rawInput[0], -1))
+	distancesCount := len(numberRegex.FindAllString(rawInput[1], -1))
+	if durationsCount != distancesCount {
+		problems = append(problems, utils.Lintf(0, "%d times but %d distances", durationsCount, distancesCount))
+	}
+
+	return problems
+}
@@ -0,0 +1,44 @@
+package day06
+
+import (
+	"testing"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintInput(t *testing.T) {
+	type test struct {
+		input    []string
+		expected []utils.LintProblem
+	}
+
+	tests := []test{
+		{
+			input:    []string{"Time:      7  15   30", "Distance:  9  40  200"},
+			expected: []utils.LintProblem{},
+		},
+		{
+			input: []string{"Time: 7 15 30", "Distance: 9 40"},
+			expected: []utils.LintProblem{
+				{Line: 0, Message: "3 times but 2 distances"},
+			},
+		},
+		{
+			input: []string{"Times: 7 15", "Distance: 9 x"},
+			expected: []utils.LintProblem{
+				{Line: 1, Message: "expected \"Time: <numbers>\", got \"Times: 7 15\""},
+				{Line: 2, Message: "expected \"Distance: <numbers>\", got \"Distance: 9 x\""},
+				{Line: 0, Message: "2 times but 1 distances"},
+			},
+		},
+		{
+			input:    []string{"Time: 7"},
+			expected: []utils.LintProblem{{Line: 0, Message: "expected 2 lines, got 1"}},
+		},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, LintInput(tc.input))
+	}
+}
@@ -1,7 +1,10 @@
-package main
+package day07
 
 import (
+	"cmp"
+	"embed"
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -9,25 +12,52 @@ import (
 	"github.com/angristan/advent-of-code-2023/utils"
 )
 
-func main() {
-	input := utils.ParseInput("input.txt")
-
-	parsedInput := ConvertRawInputToInput(input)
-	for i := range parsedInput.Hands {
-		parsedInput.Hands[i].ComputeAndAssignHandType()
-	}
-	parsedInput.SortHands(StrengthsPart1)
-	part1Score := parsedInput.ComputeTotalPoints()
-	fmt.Printf("Part 1: %d\n", part1Score)
-
-	parsedInputPart2 := ConvertRawInputToInput(input)
-	for i := range parsedInputPart2.Hands {
-		parsedInputPart2.Hands[i].ComputeAndAssignHandType()
-		parsedInputPart2.Hands[i].JokerMode()
-	}
-	parsedInputPart2.SortHands(StrengthsPart2)
-	part2Score := parsedInputPart2.ComputeTotalPoints()
-	fmt.Printf("Part 2: %d\n", part2Score)
+//go:embed input.txt example*.txt
+var inputs embed.FS
+
+// Run prints the total winnings without and with jokers, ranking the
+// cards with the configured strengths.
+func Run(args []string) error {
+	config := DefaultConfig()
+
+	return utils.DayRunner{
+		Day:    "07",
+		Inputs: inputs,
+		Config: &config,
+		Lint:   LintInput,
+		Solve: func(input []string) error {
+			parsedInput := ConvertRawInputToInput(input)
+			for i := range parsedInput.Hands {
+				parsedInput.Hands[i].ComputeAndAssignHandType()
+			}
+			parsedInput.SortHands(config.StrengthsPart1)
+			part1Score := parsedInput.ComputeTotalPoints()
+			fmt.Printf("Part 1: %d\n", part1Score)
+
+			parsedInputPart2 := ConvertRawInputToInput(input)
+			for i := range parsedInputPart2.Hands {
+				parsedInputPart2.Hands[i].ComputeAndAssignHandType()
+				parsedInputPart2.Hands[i].JokerMode()
+			}
+			parsedInputPart2.SortHands(config.StrengthsPart2)
+			part2Score := parsedInputPart2.ComputeTotalPoints()
+			fmt.Printf("Part 2: %d\n", part2Score)
+
+			return nil
+		},
+		Stream: func(options *utils.Options) error {
+			return utils.StreamInput(inputs, options, func(lines utils.LineIterator) error {
+				part1Score, part2Score, err := ComputeTotalPointsStream(lines, config.StrengthsPart1, config.StrengthsPart2)
+				if err != nil {
+					return err
+				}
+
+				fmt.Printf("Part 1: %d\n", part1Score)
+				fmt.Printf("Part 2: %d\n", part2Score)
+				return nil
+			})
+		},
+	}.Run(args)
 }
 
 type HandType int
@@ -66,22 +96,24 @@ func ConvertRawInputToInput(rawInput []string) Input {
 	input := Input{}
 
 	for _, line := range rawInput {
-		splitLine := strings.Split(line, " ")
-		cards := splitLine[0]
-		bid, err := strconv.Atoi(splitLine[1])
-		if err != nil {
-			panic(err)
-		}
+		input.Hands = append(input.Hands, ParseHand(line))
+	}
 
-		hand := Hand{
-			Cards: cards,
-			Bid:   bid,
-		}
+	return input
+}
 
-		input.Hands = append(input.Hands, hand)
+func ParseHand(line string) Hand {
+	splitLine := strings.Split(line, " ")
+	cards := splitLine[0]
+	bid, err := strconv.Atoi(splitLine[1])
+	if err != nil {
+		panic(err)
 	}
 
-	return input
+	return Hand{
+		Cards: cards,
+		Bid:   bid,
+	}
 }
 
 func (hand Hand) ComputeOcurrences() []int {
@@ -207,3 +239,88 @@ func (input Input) ComputeTotalPoints() int {
 
 	return totalPoints
 }
+
+// SortKey packs the hand type and the strength of each card into an integer
+// ordering hands the same way as SortHands.
+func (hand Hand) SortKey(strengthsMap map[string]int) uint32 {
+	return hand.sortKey(rankCards(strengthsMap))
+}
+
+// cardRanks maps each card to its rank among the cards, from 0 for the
+// weakest, so that it fits in the 4 bits SortKey gives it whatever the
+// strengths are.
+type cardRanks map[rune]uint32
+
+func rankCards(strengthsMap map[string]int) cardRanks {
+	cards := make([]string, 0, len(strengthsMap))
+	for card := range strengthsMap {
+		cards = append(cards, card)
+	}
+	slices.SortFunc(cards, func(a, b string) int {
+		return cmp.Compare(strengthsMap[a], strengthsMap[b])
+	})
+
+	ranks := cardRanks{}
+	for rank, card := range cards {
+		ranks[[]rune(card)[0]] = uint32(rank)
+	}
+
+	return ranks
+}
+
+func (hand Hand) sortKey(ranks cardRanks) uint32 {
+	key := uint32(hand.HandType)
+
+	for _, card := range hand.Cards {
+		key = key<<4 | ranks[card]
+	}
+
+	return key
+}
+
+type streamedHand struct {
+	KeyPart1 uint32
+	KeyPart2 uint32
+	Bid      int
+}
+
+// ComputeTotalPointsStream computes the total points of both parts in a
+// single pass. Ranking needs every hand, so each one is kept, but only as
+// its two sort keys and its bid instead of a parsed Hand.
+func ComputeTotalPointsStream(lines utils.LineIterator, strengthsPart1, strengthsPart2 map[string]int) (int, int, error) {
+	hands := []streamedHand{}
+	ranksPart1, ranksPart2 := rankCards(strengthsPart1), rankCards(strengthsPart2)
+
+	for lines.Scan() {
+		hand := ParseHand(lines.Text())
+		hand.ComputeAndAssignHandType()
+		keyPart1 := hand.sortKey(ranksPart1)
+
+		hand.JokerMode()
+		keyPart2 := hand.sortKey(ranksPart2)
+
+		hands = append(hands, streamedHand{KeyPart1: keyPart1, KeyPart2: keyPart2, Bid: hand.Bid})
+	}
+
+	if err := lines.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	slices.SortFunc(hands, func(a, b streamedHand) int {
+		return cmp.Compare(a.KeyPart1, b.KeyPart1)
+	})
+	part1Score := 0
+	for i, hand := range hands {
+		part1Score += hand.Bid * (i + 1)
+	}
+
+	slices.SortFunc(hands, func(a, b streamedHand) int {
+		return cmp.Compare(a.KeyPart2, b.KeyPart2)
+	})
+	part2Score := 0
+	for i, hand := range hands {
+		part2Score += hand.Bid * (i + 1)
+	}
+
+	return part1Score, part2Score, nil
+}
@@ -1,8 +1,9 @@
-package main
+package day07
 
 import (
 	"testing"
 
+	"github.com/angristan/advent-of-code-2023/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -283,3 +284,134 @@ func TestComputeTotalPoints(t *testing.T) {
 		assert.Equal(t, tc.expectedScore, tc.input.ComputeTotalPoints())
 	}
 }
+
+// readPuzzleInput returns the embedded puzzle input
+func readPuzzleInput(tb testing.TB) []string {
+	input, err := utils.ReadInput(inputs, &utils.Options{})
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	return input
+}
+
+func TestComputeTotalPointsStream(t *testing.T) {
+	type test struct {
+		input         []string
+		expectedPart1 int
+		expectedPart2 int
+	}
+
+	tests := []test{
+		{
+			input:         []string{"32T3K 765", "T55J5 684", "KK677 28", "KTJJT 220", "QQQJA 483"},
+			expectedPart1: 6440,
+			expectedPart2: 5905,
+		},
+		{
+			// Jokers turn the one pair into three of a kind in part 2
+			input:         []string{"J2344 1", "22334 2"},
+			expectedPart1: 1*1 + 2*2,
+			expectedPart2: 1*2 + 2*1,
+		},
+		{
+			input:         []string{"JJJJJ 10", "22223 20"},
+			expectedPart1: 10*2 + 20*1,
+			expectedPart2: 10*2 + 20*1,
+		},
+		{
+			input:         readPuzzleInput(t),
+			expectedPart1: 251216224,
+			expectedPart2: 250825971,
+		},
+	}
+
+	for _, tc := range tests {
+		lines := &utils.GeneratedLines{
+			Count: len(tc.input),
+			Line:  func(i int) string { return tc.input[i] },
+		}
+
+		part1Score, part2Score, err := ComputeTotalPointsStream(lines, StrengthsPart1, StrengthsPart2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expectedPart1, part1Score)
+		assert.Equal(t, tc.expectedPart2, part2Score)
+	}
+}
+
+func TestComputeTotalPointsStreamStrengths(t *testing.T) {
+	input := readPuzzleInput(t)
+
+	// scaled returns the strengths times factor plus offset
+	scaled := func(strengths map[string]int, factor, offset int) map[string]int {
+		result := map[string]int{}
+		for card, strength := range strengths {
+			result[card] = strength*factor + offset
+		}
+		return result
+	}
+
+	type test struct {
+		strengthsPart1 map[string]int
+		strengthsPart2 map[string]int
+	}
+
+	// Every table passes Config.Validate
+	tests := []test{
+		{strengthsPart1: StrengthsPart1, strengthsPart2: StrengthsPart2},
+		{strengthsPart1: scaled(StrengthsPart1, 10, 0), strengthsPart2: scaled(StrengthsPart2, 10, 0)},
+		{strengthsPart1: scaled(StrengthsPart1, 1, -20), strengthsPart2: scaled(StrengthsPart2, 100, 1000)},
+		// Aces are the weakest cards
+		{strengthsPart1: scaled(StrengthsPart1, -1, 0), strengthsPart2: scaled(StrengthsPart2, -3, 7)},
+	}
+
+	for _, tc := range tests {
+		assert.NoError(t, Config{StrengthsPart1: tc.strengthsPart1, StrengthsPart2: tc.strengthsPart2}.Validate())
+
+		parsedInput := ConvertRawInputToInput(input)
+		for i := range parsedInput.Hands {
+			parsedInput.Hands[i].ComputeAndAssignHandType()
+		}
+		parsedInput.SortHands(tc.strengthsPart1)
+		expectedPart1 := parsedInput.ComputeTotalPoints()
+
+		for i := range parsedInput.Hands {
+			parsedInput.Hands[i].JokerMode()
+		}
+		parsedInput.SortHands(tc.strengthsPart2)
+		expectedPart2 := parsedInput.ComputeTotalPoints()
+
+		lines := &utils.GeneratedLines{
+			Count: len(input),
+			Line:  func(i int) string { return input[i] },
+		}
+
+		part1Score, part2Score, err := ComputeTotalPointsStream(lines, tc.strengthsPart1, tc.strengthsPart2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedPart1, part1Score)
+		assert.Equal(t, expectedPart2, part2Score)
+	}
+}
+
+func TestComputeTotalPointsStreamMemory(t *testing.T) {
+	input := readPuzzleInput(t)
+	count := 300000
+
+	liveHeapAtEnd := uint64(0)
+	liveHeapBefore := utils.LiveHeap()
+
+	lines := &utils.GeneratedLines{
+		Count: count,
+		Line:  func(i int) string { return input[i%len(input)] },
+		AtEnd: func() { liveHeapAtEnd = utils.LiveHeap() },
+	}
+
+	_, _, err := ComputeTotalPointsStream(lines, StrengthsPart1, StrengthsPart2)
+	assert.NoError(t, err)
+
+	// Every hand has to be ranked, but only as 16 bytes of keys and bid,
+	// plus what the slice grew ahead of time.
+	assert.Less(t, int64(liveHeapAtEnd)-int64(liveHeapBefore), int64(count*32))
+}
@@ -0,0 +1,54 @@
+package day07
+
+import (
+	"fmt"
+	"maps"
+)
+
+type Config struct {
+	StrengthsPart1 map[string]int `json:"strengthsPart1"`
+	StrengthsPart2 map[string]int `json:"strengthsPart2"`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		StrengthsPart1: maps.Clone(StrengthsPart1),
+		StrengthsPart2: maps.Clone(StrengthsPart2),
+	}
+}
+
+func (config Config) Validate() error {
+	if err := validateStrengths(config.StrengthsPart1); err != nil {
+		return fmt.Errorf("strengthsPart1: %w", err)
+	}
+
+	if err := validateStrengths(config.StrengthsPart2); err != nil {
+		return fmt.Errorf("strengthsPart2: %w", err)
+	}
+
+	return nil
+}
+
+// validateStrengths makes sure every card has a strength of its own,
+// otherwise hands of the same type could compare as equal.
+func validateStrengths(strengths map[string]int) error {
+	cardsByStrength := map[int]string{}
+
+	for card := range StrengthsPart1 {
+		strength, ok := strengths[card]
+		if !ok {
+			return fmt.Errorf("missing strength for card %s", card)
+		}
+
+		if otherCard, ok := cardsByStrength[strength]; ok {
+			return fmt.Errorf("cards %s and %s have the same strength %d", otherCard, card, strength)
+		}
+		cardsByStrength[strength] = card
+	}
+
+	if len(strengths) != len(StrengthsPart1) {
+		return fmt.Errorf("expected strengths for %d cards, got %d", len(StrengthsPart1), len(strengths))
+	}
+
+	return nil
+}
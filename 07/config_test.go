@@ -0,0 +1,28 @@
+package day07
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, DefaultConfig().Validate())
+
+	config := DefaultConfig()
+	config.StrengthsPart1["A"] = config.StrengthsPart1["K"]
+	assert.Error(t, config.Validate())
+
+	config = DefaultConfig()
+	delete(config.StrengthsPart2, "J")
+	assert.Error(t, config.Validate())
+
+	config = DefaultConfig()
+	config.StrengthsPart2["X"] = 20
+	assert.Error(t, config.Validate())
+
+	// Defaults must not share the package-level tables
+	config = DefaultConfig()
+	config.StrengthsPart1["A"] = 0
+	assert.Equal(t, 13, StrengthsPart1["A"])
+}
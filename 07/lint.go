@@ -0,0 +1,25 @@
+package day07
+
+import (
+	"regexp"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+)
+
+var handLineRegex = regexp.MustCompile(`^[AKQJT2-9]{5} \d+$`)
+
+func LintInput(rawInput []string) []utils.LintProblem {
+	problems := []utils.LintProblem{}
+
+	if len(rawInput) == 0 {
+		return append(problems, utils.Lintf(0, "input is empty"))
+	}
+
+	for i, line := range rawInput {
+		if !handLineRegex.MatchString(line) {
+			problems = append(problems, utils.Lintf(i+1, "expected \"<5 cards> <bid>\", got %q", line))
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,38 @@
+package day07
+
+import (
+	"testing"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintInput(t *testing.T) {
+	type test struct {
+		input    []string
+		expected []utils.LintProblem
+	}
+
+	tests := []test{
+		{
+			input:    []string{"32T3K 765", "T55J5 684", "KK677 28"},
+			expected: []utils.LintProblem{},
+		},
+		{
+			input: []string{"32T3K 765", "T55J 684", "KK67X 28", "QQQJA"},
+			expected: []utils.LintProblem{
+				{Line: 2, Message: "expected \"<5 cards> <bid>\", got \"T55J 684\""},
+				{Line: 3, Message: "expected \"<5 cards> <bid>\", got \"KK67X 28\""},
+				{Line: 4, Message: "expected \"<5 cards> <bid>\", got \"QQQJA\""},
+			},
+		},
+		{
+			input:    []string{},
+			expected: []utils.LintProblem{{Line: 0, Message: "input is empty"}},
+		},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, LintInput(tc.input))
+	}
+}
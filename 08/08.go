@@ -1,19 +1,70 @@
-package main
+package day08
 
 import (
+	"embed"
 	"fmt"
+	"os"
 	"regexp"
 
 	"github.com/angristan/advent-of-code-2023/utils"
 )
 
-func main() {
-	input := utils.ParseInput("input.txt")
+//go:embed input.txt example*.txt
+var inputs embed.FS
 
-	m := ConvertRawInputToMap(input)
-	fmt.Printf("Part 1: %d\n", m.StepsCountToZZZ())
+// Run prints the steps between the configured start and end nodes, then
+// the steps for every ghost to reach an end node, or explores the network
+// with the repl subcommand.
+func Run(args []string) error {
+	config := DefaultConfig()
 
-	fmt.Printf("Part 2: %d\n", m.StepsCountToEndingZGhostMode())
+	// parse reads the network and checks the configured nodes are in it
+	parse := func(input []string) (Map, error) {
+		m := ConvertRawInputToMap(input)
+		if err := config.ValidateNetwork(m); err != nil {
+			return Map{}, fmt.Errorf("day 08: %w", err)
+		}
+
+		return m, nil
+	}
+
+	return utils.DayRunner{
+		Day:    "08",
+		Inputs: inputs,
+		Config: &config,
+		Lint: func(input []string) []utils.LintProblem {
+			return LintInput(input, config)
+		},
+		Solve: func(input []string) error {
+			m, err := parse(input)
+			if err != nil {
+				return err
+			}
+
+			part1Steps, err := m.StepsCountBetween(config.Start, config.End)
+			if err != nil {
+				return fmt.Errorf("day 08: %w", err)
+			}
+			fmt.Printf("Part 1: %d\n", part1Steps)
+
+			fmt.Printf("Part 2: %d\n", m.StepsCountToEndingZGhostMode())
+
+			return nil
+		},
+		Subcommands: map[string]utils.Subcommand{
+			"repl": {
+				Usage: "repl",
+				Run: func(input []string, options *utils.Options) error {
+					m, err := parse(input)
+					if err != nil {
+						return err
+					}
+
+					return m.REPL(config).Run(os.Stdin, os.Stdout)
+				},
+			},
+		},
+	}.Run(args)
 }
 
 type Direction string
@@ -64,11 +115,31 @@ func ConvertRawInputToMap(input []string) Map {
 	return m
 }
 
-func (m Map) StepsCountToZZZ() int {
+func (m Map) StepsCountToZZZ() (int, error) {
+	return m.StepsCountBetween("AAA", "ZZZ")
+}
+
+// StepsCountBetween counts the steps following the directions from start
+// until end is reached. A walk is in one of the nodes at one of the
+// directions, so after that many steps without reaching end, it is
+// looping and never will.
+func (m Map) StepsCountBetween(start, end string) (int, error) {
+	currentNode, ok := m.Nodes[start]
+	if !ok {
+		return 0, fmt.Errorf("unknown node %s", start)
+	}
+	if _, ok := m.Nodes[end]; !ok {
+		return 0, fmt.Errorf("unknown node %s", end)
+	}
+
+	maxSteps := len(m.Nodes) * len(m.Directions)
 	count := 0
-	currentNode := m.Nodes["AAA"]
 
-	for currentNode.Value != "ZZZ" {
+	for currentNode.Value != end {
+		if count == maxSteps {
+			return 0, fmt.Errorf("%s can't be reached from %s", end, start)
+		}
+
 		if m.Directions[count%len(m.Directions)] == Left {
 			currentNode = m.Nodes[currentNode.Left]
 		} else {
@@ -78,7 +149,37 @@ func (m Map) StepsCountToZZZ() int {
 		count++
 	}
 
-	return count
+	return count, nil
+}
+
+// PathBetween follows the directions from start until end is reached,
+// giving up after maxSteps so that unreachable ends don't loop forever.
+func (m Map) PathBetween(start, end string, maxSteps int) ([]string, error) {
+	currentNode, ok := m.Nodes[start]
+	if !ok {
+		return nil, fmt.Errorf("unknown node %s", start)
+	}
+	if _, ok := m.Nodes[end]; !ok {
+		return nil, fmt.Errorf("unknown node %s", end)
+	}
+
+	path := []string{currentNode.Value}
+
+	for count := 0; currentNode.Value != end; count++ {
+		if count == maxSteps {
+			return nil, fmt.Errorf("%s not reached from %s after %d steps", end, start, maxSteps)
+		}
+
+		if m.Directions[count%len(m.Directions)] == Left {
+			currentNode = m.Nodes[currentNode.Left]
+		} else {
+			currentNode = m.Nodes[currentNode.Right]
+		}
+
+		path = append(path, currentNode.Value)
+	}
+
+	return path, nil
 }
 
 func (m Map) StepsCountToEndingZGhostMode() int {
@@ -1,4 +1,4 @@
-package main
+package day08
 
 import (
 	"testing"
@@ -133,10 +133,67 @@ func TestStepsCountToZZZ(t *testing.T) {
 	}
 
 	for _, v := range tests {
-		assert.Equal(t, v.expected, v.input.StepsCountToZZZ())
+		steps, err := v.input.StepsCountToZZZ()
+		assert.NoError(t, err)
+		assert.Equal(t, v.expected, steps)
 	}
 }
 
+func TestStepsCountBetweenUnreachable(t *testing.T) {
+	m := Map{
+		Directions: []Direction{Left, Left, Right},
+		Nodes: map[string]Node{
+			"AAA": {Value: "AAA", Left: "BBB", Right: "BBB"},
+			"BBB": {Value: "BBB", Left: "AAA", Right: "ZZZ"},
+			"ZZZ": {Value: "ZZZ", Left: "ZZZ", Right: "ZZZ"},
+		},
+	}
+
+	type test struct {
+		start   string
+		end     string
+		wantErr string
+	}
+
+	tests := []test{
+		// ZZZ only leads to itself
+		{start: "ZZZ", end: "AAA", wantErr: "AAA can't be reached from ZZZ"},
+		{start: "XXX", end: "ZZZ", wantErr: "unknown node XXX"},
+		{start: "AAA", end: "XXX", wantErr: "unknown node XXX"},
+	}
+
+	for _, tt := range tests {
+		_, err := m.StepsCountBetween(tt.start, tt.end)
+		assert.EqualError(t, err, tt.wantErr)
+	}
+
+	steps, err := m.StepsCountBetween("BBB", "AAA")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, steps)
+}
+
+func TestPathBetween(t *testing.T) {
+	m := Map{
+		Directions: []Direction{Left, Left, Right},
+		Nodes: map[string]Node{
+			"AAA": {Value: "AAA", Left: "BBB", Right: "BBB"},
+			"BBB": {Value: "BBB", Left: "AAA", Right: "ZZZ"},
+			"CCC": {Value: "CCC", Left: "CCC", Right: "CCC"},
+			"ZZZ": {Value: "ZZZ", Left: "ZZZ", Right: "ZZZ"},
+		},
+	}
+
+	path, err := m.PathBetween("AAA", "ZZZ", 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"AAA", "BBB", "AAA", "BBB", "AAA", "BBB", "ZZZ"}, path)
+
+	_, err = m.PathBetween("AAA", "CCC", 100)
+	assert.Error(t, err)
+
+	_, err = m.PathBetween("XXX", "ZZZ", 100)
+	assert.Error(t, err)
+}
+
 func TestStepsCountToEndingZGhostMode(t *testing.T) {
 	type test struct {
 		input    Map
@@ -167,3 +224,34 @@ func TestStepsCountToEndingZGhostMode(t *testing.T) {
 		assert.Equal(t, v.expected, v.input.StepsCountToEndingZGhostMode())
 	}
 }
+
+func TestConfigValidateNetwork(t *testing.T) {
+	m := ConvertRawInputToMap([]string{
+		"LLR",
+		"",
+		"AAA = (BBB, BBB)",
+		"BBB = (AAA, ZZZ)",
+		"ZZZ = (ZZZ, ZZZ)",
+	})
+
+	type test struct {
+		config   Config
+		expected string
+	}
+
+	tests := []test{
+		{config: Config{Start: "AAA", End: "ZZZ"}, expected: ""},
+		{config: Config{Start: "BBB", End: "AAA"}, expected: ""},
+		{config: Config{Start: "XXX", End: "ZZZ"}, expected: "node XXX is not in the network"},
+		{config: Config{Start: "AAA", End: "YYY"}, expected: "node YYY is not in the network"},
+	}
+
+	for _, test := range tests {
+		err := test.config.ValidateNetwork(m)
+		if test.expected == "" {
+			assert.NoError(t, err)
+		} else {
+			assert.EqualError(t, err, test.expected)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package day08
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Config struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Start: "AAA",
+		End:   "ZZZ",
+	}
+}
+
+func (config Config) Validate() error {
+	if config.Start == "" || config.End == "" {
+		return errors.New("start and end nodes are required")
+	}
+
+	if config.Start == config.End {
+		return errors.New("start and end nodes must be different")
+	}
+
+	return nil
+}
+
+// ValidateNetwork checks that the start and end nodes exist in the network,
+// which Validate can't know about as the configuration is loaded first.
+func (config Config) ValidateNetwork(m Map) error {
+	for _, label := range []string{config.Start, config.End} {
+		if _, ok := m.Nodes[label]; !ok {
+			return fmt.Errorf("node %s is not in the network", label)
+		}
+	}
+
+	return nil
+}
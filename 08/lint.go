@@ -0,0 +1,62 @@
+package day08
+
+import (
+	"regexp"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+)
+
+var directionsRegex = regexp.MustCompile(`^[LR]+$`)
+
+func LintInput(input []string, config Config) []utils.LintProblem {
+	problems := []utils.LintProblem{}
+
+	if len(input) < 3 {
+		return append(problems, utils.Lintf(0, "expected directions and at least one node, got %d lines", len(input)))
+	}
+
+	if !directionsRegex.MatchString(input[0]) {
+		problems = append(problems, utils.Lintf(1, "expected directions made of L and R, got %q", input[0]))
+	}
+
+	if input[1] != "" {
+		problems = append(problems, utils.Lintf(2, "expected an empty line after directions, got %q", input[1]))
+	}
+
+	nodeLines := map[string]int{}
+	nodes := []Node{}
+
+	for i, line := range input[2:] {
+		lineNumber := i + 3
+
+		matches := matchNodesRegex.FindStringSubmatch(line)
+		if matches == nil || len(matches[1]) != 3 {
+			problems = append(problems, utils.Lintf(lineNumber, "expected \"XXX = (XXX, XXX)\", got %q", line))
+			continue
+		}
+
+		if firstLine, ok := nodeLines[matches[1]]; ok {
+			problems = append(problems, utils.Lintf(lineNumber, "node %s already defined on line %d", matches[1], firstLine))
+			continue
+		}
+
+		nodeLines[matches[1]] = lineNumber
+		nodes = append(nodes, Node{Value: matches[1], Left: matches[2], Right: matches[3]})
+	}
+
+	for _, node := range nodes {
+		for _, next := range []string{node.Left, node.Right} {
+			if _, ok := nodeLines[next]; !ok {
+				problems = append(problems, utils.Lintf(nodeLines[node.Value], "node %s leads to undefined node %s", node.Value, next))
+			}
+		}
+	}
+
+	for _, label := range []string{config.Start, config.End} {
+		if _, ok := nodeLines[label]; !ok {
+			problems = append(problems, utils.Lintf(0, "node %s is not defined", label))
+		}
+	}
+
+	return problems
+}
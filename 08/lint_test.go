@@ -0,0 +1,29 @@
+package day08
+
+import (
+	"testing"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintInput(t *testing.T) {
+	input := []string{
+		"LLRX",
+		"",
+		"AAA = (BBB, BBB)",
+		"BBB = (AAA, ZZZ)",
+		"BBB = (AAA, AAA)",
+		"CC = (AAA, AAA)",
+	}
+
+	want := []utils.LintProblem{
+		{Line: 1, Message: "expected directions made of L and R, got \"LLRX\""},
+		{Line: 5, Message: "node BBB already defined on line 4"},
+		{Line: 6, Message: "expected \"XXX = (XXX, XXX)\", got \"CC = (AAA, AAA)\""},
+		{Line: 4, Message: "node BBB leads to undefined node ZZZ"},
+		{Line: 0, Message: "node ZZZ is not defined"},
+	}
+
+	assert.Equal(t, want, LintInput(input, DefaultConfig()))
+}
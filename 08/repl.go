@@ -0,0 +1,62 @@
+package day08
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+)
+
+const replMaxSteps = 1000000
+
+func (m Map) REPL(config Config) utils.REPL {
+	return utils.REPL{
+		Prompt: "day08> ",
+		Commands: map[string]utils.REPLCommand{
+			"node": {
+				Usage: "node <label>",
+				Run: func(args []string) (string, error) {
+					if len(args) != 1 {
+						return "", fmt.Errorf("expected 1 argument, got %d", len(args))
+					}
+
+					node, ok := m.Nodes[args[0]]
+					if !ok {
+						return "", fmt.Errorf("unknown node %s", args[0])
+					}
+
+					return fmt.Sprintf("%s = (%s, %s)", node.Value, node.Left, node.Right), nil
+				},
+			},
+			"path": {
+				Usage: "path [start] [end]",
+				Run: func(args []string) (string, error) {
+					if len(args) > 2 {
+						return "", fmt.Errorf("expected at most 2 arguments, got %d", len(args))
+					}
+
+					start, end := config.Start, config.End
+					if len(args) >= 1 {
+						start = args[0]
+					}
+					if len(args) == 2 {
+						end = args[1]
+					}
+
+					path, err := m.PathBetween(start, end, replMaxSteps)
+					if err != nil {
+						return "", err
+					}
+
+					return fmt.Sprintf("%d steps: %s", len(path)-1, strings.Join(path, " -> ")), nil
+				},
+			},
+			"starts": {
+				Usage: "starts",
+				Run: func(args []string) (string, error) {
+					return strings.Join(m.EndingANodesKeys, " "), nil
+				},
+			},
+		},
+	}
+}
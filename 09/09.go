@@ -1,6 +1,7 @@
-package main
+package day09
 
 import (
+	"embed"
 	"fmt"
 	"regexp"
 	"slices"
@@ -9,14 +10,40 @@ import (
 	"github.com/angristan/advent-of-code-2023/utils"
 )
 
-func main() {
-	input := utils.ParseInput("input.txt")
-
-	report := ConvertRawInputToReport(input)
-	fmt.Printf("Part 1: %d\n", report.ComputeSumOfNextValues())
-
-	report = ConvertRawInputToReport(input)
-	fmt.Printf("Part 2: %d\n", report.ComputeSumOfPreviousValues())
+//go:embed input.txt example*.txt
+var inputs embed.FS
+
+// Run prints the sums of the extrapolated next and previous values.
+func Run(args []string) error {
+	config := utils.NoConfig{}
+
+	return utils.DayRunner{
+		Day:    "09",
+		Inputs: inputs,
+		Config: &config,
+		Lint:   LintInput,
+		Solve: func(input []string) error {
+			report := ConvertRawInputToReport(input)
+			fmt.Printf("Part 1: %d\n", report.ComputeSumOfNextValues())
+
+			report = ConvertRawInputToReport(input)
+			fmt.Printf("Part 2: %d\n", report.ComputeSumOfPreviousValues())
+
+			return nil
+		},
+		Stream: func(options *utils.Options) error {
+			return utils.StreamInput(inputs, options, func(lines utils.LineIterator) error {
+				nextValuesSum, previousValuesSum, err := ComputeSumsStream(lines)
+				if err != nil {
+					return err
+				}
+
+				fmt.Printf("Part 1: %d\n", nextValuesSum)
+				fmt.Printf("Part 2: %d\n", previousValuesSum)
+				return nil
+			})
+		},
+	}.Run(args)
 }
 
 type History struct {
@@ -33,26 +60,28 @@ func ConvertRawInputToReport(rawInput []string) Report {
 	report := Report{}
 
 	for _, rawHistory := range rawInput {
-		rawValues := numberRegex.FindAllString(rawHistory, -1)
+		report.Histories = append(report.Histories, ParseHistory(rawHistory))
+	}
 
-		values := []int{}
-		for _, rawValue := range rawValues {
-			value, err := strconv.Atoi(rawValue)
-			if err != nil {
-				panic(err)
-			}
+	return report
+}
 
-			values = append(values, value)
-		}
+func ParseHistory(rawHistory string) History {
+	rawValues := numberRegex.FindAllString(rawHistory, -1)
 
-		history := History{
-			Values: values,
+	values := []int{}
+	for _, rawValue := range rawValues {
+		value, err := strconv.Atoi(rawValue)
+		if err != nil {
+			panic(err)
 		}
 
-		report.Histories = append(report.Histories, history)
+		values = append(values, value)
 	}
 
-	return report
+	return History{
+		Values: values,
+	}
 }
 
 func (history History) ComputeNextValue() int {
@@ -113,3 +142,20 @@ func (report Report) ComputeSumOfPreviousValues() int {
 
 	return sum
 }
+
+// ComputeSumsStream computes the sums of next and previous values
+// while only holding the current history in memory.
+func ComputeSumsStream(lines utils.LineIterator) (int, int, error) {
+	nextValuesSum := 0
+	previousValuesSum := 0
+
+	for lines.Scan() {
+		history := ParseHistory(lines.Text())
+
+		// ComputePreviousValue reverses the values in place, so it goes last
+		nextValuesSum += history.ComputeNextValue()
+		previousValuesSum += history.ComputePreviousValue()
+	}
+
+	return nextValuesSum, previousValuesSum, lines.Err()
+}
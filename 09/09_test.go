@@ -1,8 +1,9 @@
-package main
+package day09
 
 import (
 	"testing"
 
+	"github.com/angristan/advent-of-code-2023/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -69,3 +70,27 @@ func TestComputeSumOfNextValues(t *testing.T) {
 
 	assert.Equal(t, expected, input.ComputeSumOfNextValues())
 }
+
+func TestComputeSumsStream(t *testing.T) {
+	input := []string{
+		"0 3 6 9 12 15",
+		"1 3 6 10 15 21",
+		"10 13 16 21 30 45",
+	}
+
+	liveHeapAtEnd := uint64(0)
+	liveHeapBefore := utils.LiveHeap()
+
+	lines := &utils.GeneratedLines{
+		Count: len(input) * 100000,
+		Line:  func(i int) string { return input[i%len(input)] },
+		AtEnd: func() { liveHeapAtEnd = utils.LiveHeap() },
+	}
+
+	nextValuesSum, previousValuesSum, err := ComputeSumsStream(lines)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 114*100000, nextValuesSum)
+	assert.Equal(t, 2*100000, previousValuesSum)
+	assert.Less(t, int64(liveHeapAtEnd)-int64(liveHeapBefore), int64(64*1024))
+}
@@ -0,0 +1,25 @@
+package day09
+
+import (
+	"regexp"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+)
+
+var historyLineRegex = regexp.MustCompile(`^-?\d+( -?\d+)+$`)
+
+func LintInput(rawInput []string) []utils.LintProblem {
+	problems := []utils.LintProblem{}
+
+	if len(rawInput) == 0 {
+		return append(problems, utils.Lintf(0, "input is empty"))
+	}
+
+	for i, line := range rawInput {
+		if !historyLineRegex.MatchString(line) {
+			problems = append(problems, utils.Lintf(i+1, "expected at least 2 space-separated integers, got %q", line))
+		}
+	}
+
+	return problems
+}
@@ -0,0 +1,38 @@
+package day09
+
+import (
+	"testing"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintInput(t *testing.T) {
+	type test struct {
+		input    []string
+		expected []utils.LintProblem
+	}
+
+	tests := []test{
+		{
+			input:    []string{"0 3 6 9 12 15", "-1 -3 -5"},
+			expected: []utils.LintProblem{},
+		},
+		{
+			input: []string{"0 3 6 9 12 15", "42", "1  3", "1 x 3"},
+			expected: []utils.LintProblem{
+				{Line: 2, Message: "expected at least 2 space-separated integers, got \"42\""},
+				{Line: 3, Message: "expected at least 2 space-separated integers, got \"1  3\""},
+				{Line: 4, Message: "expected at least 2 space-separated integers, got \"1 x 3\""},
+			},
+		},
+		{
+			input:    []string{},
+			expected: []utils.LintProblem{{Line: 0, Message: "input is empty"}},
+		},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, LintInput(tc.input))
+	}
+}
@@ -1,20 +1,54 @@
-package main
+package day10
 
 import (
+	"embed"
+	"errors"
 	"fmt"
 	"math"
+	"os"
 
 	"github.com/angristan/advent-of-code-2023/utils"
 )
 
-func main() {
-	input := utils.ParseInput("input.txt")
-
-	grid := ConvertRawInputToSurfacePipes(input)
-	fmt.Printf("Part 1: %d\n", grid.GetFurthestPipeFromStartStepsCount())
+//go:embed input.txt example*.txt
+var inputs embed.FS
+
+// Run prints the steps to the farthest pipe of the loop and the count of
+// tiles it encloses, or explores the grid with the repl subcommand.
+func Run(args []string) error {
+	config := utils.NoConfig{}
+
+	return utils.DayRunner{
+		Day:    "10",
+		Inputs: inputs,
+		Config: &config,
+		Lint:   LintInput,
+		Solve: func(input []string) error {
+			grid := ConvertRawInputToSurfacePipes(input)
+
+			steps, err := grid.GetFurthestPipeFromStartStepsCount()
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Part 1: %d\n", steps)
 
-	enclosedTiles := grid.GetEnclosedTiles()
-	fmt.Printf("Part 2: %d\n", len(enclosedTiles))
+			enclosedTiles, err := grid.GetEnclosedTiles()
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Part 2: %d\n", len(enclosedTiles))
+
+			return nil
+		},
+		Subcommands: map[string]utils.Subcommand{
+			"repl": {
+				Usage: "repl",
+				Run: func(input []string, options *utils.Options) error {
+					return ConvertRawInputToSurfacePipes(input).REPL().Run(os.Stdin, os.Stdout)
+				},
+			},
+		},
+	}.Run(args)
 }
 
 type TileType string
@@ -57,16 +91,16 @@ func ConvertRawInputToSurfacePipes(rawInput []string) TheGrid {
 	return surfacePipes
 }
 
-func (sp TheGrid) GetStartPipe() Tile {
+func (sp TheGrid) GetStartPipe() (Tile, error) {
 	for _, row := range sp {
 		for _, pipe := range row {
 			if pipe.Type == Start {
-				return pipe
+				return pipe, nil
 			}
 		}
 	}
 
-	panic("No start pipe found")
+	return Tile{}, errors.New("no start pipe found")
 }
 
 func (grid TheGrid) GetAdjacentTiles(tile Tile) []Tile {
@@ -100,7 +134,9 @@ func (grid TheGrid) GetAdjacentPipes(tile Tile) []Tile {
 	return adjacentPipes
 }
 
-func (grid TheGrid) GetConnectedPipes(tile Tile) []Tile {
+// GetConnectedPipes returns the two pipes connected to tile, which fails
+// for pipes that aren't part of a loop.
+func (grid TheGrid) GetConnectedPipes(tile Tile) ([]Tile, error) {
 	connectedPipes := []Tile{}
 
 	for _, adjacentPipe := range grid.GetAdjacentPipes(tile) {
@@ -145,24 +181,34 @@ func (grid TheGrid) GetConnectedPipes(tile Tile) []Tile {
 
 	switch len(connectedPipes) {
 	case 1:
-		connectedPipes = append(connectedPipes, grid.GetStartPipe())
+		startPipe, err := grid.GetStartPipe()
+		if err != nil {
+			return nil, err
+		}
+		connectedPipes = append(connectedPipes, startPipe)
 	case 2:
 	default:
-		panic("Invalid number of connected pipes")
+		return nil, fmt.Errorf("%d pipes connected to (%d, %d), expected 2", len(connectedPipes), tile.CoordX, tile.CoordY)
 	}
 
-	return connectedPipes
+	return connectedPipes, nil
 }
 
-func (grid TheGrid) GetFurthestPipeFromStartStepsCount() int {
+func (grid TheGrid) GetFurthestPipeFromStartStepsCount() (int, error) {
 	steps := 0
 
 	lastPipe := Tile{}
 	var currentPipe Tile
-	nextPipe := grid.GetStartPipe()
+	nextPipe, err := grid.GetStartPipe()
+	if err != nil {
+		return 0, err
+	}
 	for {
 		currentPipe = nextPipe
-		connectedPipes := grid.GetConnectedPipes(currentPipe)
+		connectedPipes, err := grid.GetConnectedPipes(currentPipe)
+		if err != nil {
+			return 0, err
+		}
 
 		if connectedPipes[0] != lastPipe {
 			nextPipe = connectedPipes[0]
@@ -178,22 +224,28 @@ func (grid TheGrid) GetFurthestPipeFromStartStepsCount() int {
 		}
 	}
 
-	return int(math.Round(float64(steps) / 2))
+	return int(math.Round(float64(steps) / 2)), nil
 }
 
 type Coord struct {
 	X, Y int
 }
 
-func (grid TheGrid) GetLoopTiles() map[Coord]Tile {
+func (grid TheGrid) GetLoopTiles() (map[Coord]Tile, error) {
 	loopMap := map[Coord]Tile{}
 
 	lastPipe := Tile{}
 	var currentPipe Tile
-	nextPipe := grid.GetStartPipe()
+	nextPipe, err := grid.GetStartPipe()
+	if err != nil {
+		return nil, err
+	}
 	for {
 		currentPipe = nextPipe
-		connectedPipes := grid.GetConnectedPipes(currentPipe)
+		connectedPipes, err := grid.GetConnectedPipes(currentPipe)
+		if err != nil {
+			return nil, err
+		}
 
 		if connectedPipes[0] != lastPipe {
 			nextPipe = connectedPipes[0]
@@ -209,11 +261,14 @@ func (grid TheGrid) GetLoopTiles() map[Coord]Tile {
 		}
 	}
 
-	return loopMap
+	return loopMap, nil
 }
 
-func (grid TheGrid) GetEnclosedTiles() []Tile {
-	loopMap := grid.GetLoopTiles()
+func (grid TheGrid) GetEnclosedTiles() ([]Tile, error) {
+	loopMap, err := grid.GetLoopTiles()
+	if err != nil {
+		return nil, err
+	}
 	enclosedTiles := []Tile{}
 
 	for y, row := range grid {
@@ -295,5 +350,5 @@ func (grid TheGrid) GetEnclosedTiles() []Tile {
 		}
 	}
 
-	return enclosedTiles
+	return enclosedTiles, nil
 }
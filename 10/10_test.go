@@ -1,4 +1,4 @@
-package main
+package day10
 
 import (
 	"testing"
@@ -136,8 +136,13 @@ func TestGetStartPipe(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
-		assert.Equal(t, testCase.expected, testCase.grid.GetStartPipe())
+		startPipe, err := testCase.grid.GetStartPipe()
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expected, startPipe)
 	}
+
+	_, err := ConvertRawInputToSurfacePipes([]string{"-L|", "7.-"}).GetStartPipe()
+	assert.EqualError(t, err, "no start pipe found")
 }
 
 func TestGetAdjacentTiles(t *testing.T) {
@@ -234,8 +239,14 @@ func TestGetConnectedPipes(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
-		assert.Equal(t, testCase.expected, testCase.grid.GetConnectedPipes(testCase.tile))
+		connectedPipes, err := testCase.grid.GetConnectedPipes(testCase.tile)
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expected, connectedPipes)
 	}
+
+	// Nothing connects to the horizontal pipe in the corner
+	_, err := Grid1.GetConnectedPipes(Tile{Type: PipeHorizontal, CoordX: 0, CoordY: 0})
+	assert.EqualError(t, err, "0 pipes connected to (0, 0), expected 2")
 }
 
 func TestGetFurthestPipeFromStartStepsCount(t *testing.T) {
@@ -256,7 +267,9 @@ func TestGetFurthestPipeFromStartStepsCount(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
-		assert.Equal(t, testCase.expected, testCase.grid.GetFurthestPipeFromStartStepsCount())
+		steps, err := testCase.grid.GetFurthestPipeFromStartStepsCount()
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expected, steps)
 	}
 }
 
@@ -339,6 +352,8 @@ func TestGetEnclosedTiles(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
-		assert.Equal(t, testCase.expected, ConvertRawInputToSurfacePipes(testCase.grid).GetEnclosedTiles())
+		enclosedTiles, err := ConvertRawInputToSurfacePipes(testCase.grid).GetEnclosedTiles()
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expected, enclosedTiles)
 	}
 }
@@ -0,0 +1,30 @@
+package day10
+
+import (
+	"strings"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+)
+
+func LintInput(rawInput []string) []utils.LintProblem {
+	problems := utils.LintGrid(rawInput, "|-LJ7F.S")
+
+	starts := []int{}
+	for y, row := range rawInput {
+		for i := 0; i < strings.Count(row, string(Start)); i++ {
+			starts = append(starts, y+1)
+		}
+	}
+
+	switch len(starts) {
+	case 0:
+		problems = append(problems, utils.Lintf(0, "no start tile %s found", Start))
+	case 1:
+	default:
+		for _, line := range starts {
+			problems = append(problems, utils.Lintf(line, "one of %d start tiles", len(starts)))
+		}
+	}
+
+	return problems
+}
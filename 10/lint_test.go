@@ -0,0 +1,56 @@
+package day10
+
+import (
+	"testing"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintInput(t *testing.T) {
+	type test struct {
+		input    []string
+		expected []utils.LintProblem
+	}
+
+	tests := []test{
+		{
+			input: []string{
+				".....",
+				".S-7.",
+				".|.|.",
+				".L-J.",
+				".....",
+			},
+			expected: []utils.LintProblem{},
+		},
+		{
+			input: []string{
+				".....",
+				".F-7.",
+				".|.|",
+				".L-J.",
+			},
+			expected: []utils.LintProblem{
+				{Line: 3, Message: "width is 4, expected 5 like line 1"},
+				{Line: 0, Message: "no start tile S found"},
+			},
+		},
+		{
+			input: []string{
+				"S-7",
+				"|X|",
+				"L-S",
+			},
+			expected: []utils.LintProblem{
+				{Line: 2, Message: "unexpected character 'X' at column 2"},
+				{Line: 1, Message: "one of 2 start tiles"},
+				{Line: 3, Message: "one of 2 start tiles"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, LintInput(tc.input))
+	}
+}
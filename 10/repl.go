@@ -0,0 +1,95 @@
+package day10
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+)
+
+func (grid TheGrid) REPL() utils.REPL {
+	return utils.REPL{
+		Prompt: "day10> ",
+		Commands: map[string]utils.REPLCommand{
+			"tile": {
+				Usage: "tile <x> <y>",
+				Run: func(args []string) (string, error) {
+					tile, err := grid.lookupTile(args)
+					if err != nil {
+						return "", err
+					}
+
+					return formatTile(tile), nil
+				},
+			},
+			"neighbours": {
+				Usage: "neighbours <x> <y>",
+				Run: func(args []string) (string, error) {
+					tile, err := grid.lookupTile(args)
+					if err != nil {
+						return "", err
+					}
+
+					return formatTiles(grid.GetAdjacentTiles(tile)), nil
+				},
+			},
+			"connected": {
+				Usage: "connected <x> <y>",
+				Run: func(args []string) (string, error) {
+					tile, err := grid.lookupTile(args)
+					if err != nil {
+						return "", err
+					}
+					if tile.Type == PipeGround {
+						return "", fmt.Errorf("%s is ground", formatTile(tile))
+					}
+
+					connectedPipes, err := grid.GetConnectedPipes(tile)
+					if err != nil {
+						return "", err
+					}
+
+					return formatTiles(connectedPipes), nil
+				},
+			},
+			"start": {
+				Usage: "start",
+				Run: func(args []string) (string, error) {
+					startPipe, err := grid.GetStartPipe()
+					if err != nil {
+						return "", err
+					}
+
+					return formatTile(startPipe), nil
+				},
+			},
+		},
+	}
+}
+
+func (grid TheGrid) lookupTile(args []string) (Tile, error) {
+	values, err := utils.IntArgs(args, 2)
+	if err != nil {
+		return Tile{}, err
+	}
+
+	x, y := values[0], values[1]
+	if y < 0 || y >= len(grid) || x < 0 || x >= len(grid[y]) {
+		return Tile{}, fmt.Errorf("(%d, %d) is outside the grid", x, y)
+	}
+
+	return grid[y][x], nil
+}
+
+func formatTile(tile Tile) string {
+	return fmt.Sprintf("%s at (%d, %d)", tile.Type, tile.CoordX, tile.CoordY)
+}
+
+func formatTiles(tiles []Tile) string {
+	formatted := make([]string, 0, len(tiles))
+	for _, tile := range tiles {
+		formatted = append(formatted, formatTile(tile))
+	}
+
+	return strings.Join(formatted, "\n")
+}
@@ -0,0 +1,38 @@
+package day10
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestREPL(t *testing.T) {
+	type test struct {
+		grid    TheGrid
+		command string
+		want    string
+	}
+
+	noStart := ConvertRawInputToSurfacePipes([]string{"F7", "LJ"})
+
+	tests := []test{
+		{grid: Grid2, command: "start", want: "S at (0, 2)"},
+		{grid: noStart, command: "start", want: "error: no start pipe found"},
+		{grid: Grid2, command: "tile 3 1", want: "| at (3, 1)"},
+		{grid: Grid2, command: "tile 5 0", want: "error: (5, 0) is outside the grid"},
+		{grid: Grid2, command: "neighbours 0 2", want: "J at (1, 2)\n. at (0, 1)\n| at (0, 3)"},
+		{grid: Grid2, command: "connected 0 2", want: "J at (1, 2)\n| at (0, 3)"},
+		{grid: Grid2, command: "connected 0 1", want: "error: . at (0, 1) is ground"},
+		{grid: Grid1, command: "connected 0 0", want: "error: 0 pipes connected to (0, 0), expected 2"},
+		// A pipe connected to a single other pipe is next to the start
+		{grid: ConvertRawInputToSurfacePipes([]string{"--"}), command: "connected 0 0", want: "error: no start pipe found"},
+	}
+
+	for _, tt := range tests {
+		out := strings.Builder{}
+
+		assert.NoError(t, tt.grid.REPL().Run(strings.NewReader(tt.command+"\n"), &out))
+		assert.Equal(t, "day10> "+tt.want+"\nday10> \n", out.String(), tt.command)
+	}
+}
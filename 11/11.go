@@ -1,6 +1,7 @@
-package main
+package day11
 
 import (
+	"embed"
 	"fmt"
 	"math"
 	"slices"
@@ -8,13 +9,28 @@ import (
 	"github.com/angristan/advent-of-code-2023/utils"
 )
 
-func main() {
-	input := utils.ParseInput("input.txt")
+//go:embed input.txt example*.txt
+var inputs embed.FS
 
-	image := ConvertRawInputToImage(input)
-	fmt.Printf("Part 1: %d\n", image.SumShortestPathBetweenAllGalaxies(2))
+// Run prints the sum of the distances between galaxies under both
+// configured expansion factors.
+func Run(args []string) error {
+	config := DefaultConfig()
 
-	fmt.Printf("Part 2: %d\n", image.SumShortestPathBetweenAllGalaxies(1000000))
+	return utils.DayRunner{
+		Day:    "11",
+		Inputs: inputs,
+		Config: &config,
+		Lint:   LintInput,
+		Solve: func(input []string) error {
+			image := ConvertRawInputToImage(input)
+			fmt.Printf("Part 1: %d\n", image.SumShortestPathBetweenAllGalaxies(config.ExpansionFactorPart1))
+
+			fmt.Printf("Part 2: %d\n", image.SumShortestPathBetweenAllGalaxies(config.ExpansionFactorPart2))
+
+			return nil
+		},
+	}.Run(args)
 }
 
 type Pixel string
@@ -0,0 +1,30 @@
+package day11
+
+import (
+	"fmt"
+)
+
+type Config struct {
+	ExpansionFactorPart1 int `json:"expansionFactorPart1"`
+	ExpansionFactorPart2 int `json:"expansionFactorPart2"`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		ExpansionFactorPart1: 2,
+		ExpansionFactorPart2: 1000000,
+	}
+}
+
+func (config Config) Validate() error {
+	// A factor of 1 means that empty rows and columns don't expand
+	if config.ExpansionFactorPart1 < 1 {
+		return fmt.Errorf("expansionFactorPart1 must be at least 1, got %d", config.ExpansionFactorPart1)
+	}
+
+	if config.ExpansionFactorPart2 < 1 {
+		return fmt.Errorf("expansionFactorPart2 must be at least 1, got %d", config.ExpansionFactorPart2)
+	}
+
+	return nil
+}
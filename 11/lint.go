@@ -0,0 +1,9 @@
+package day11
+
+import (
+	"github.com/angristan/advent-of-code-2023/utils"
+)
+
+func LintInput(rawInput []string) []utils.LintProblem {
+	return utils.LintGrid(rawInput, string(s)+string(G))
+}
@@ -0,0 +1,37 @@
+package day11
+
+import (
+	"testing"
+
+	"github.com/angristan/advent-of-code-2023/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintInput(t *testing.T) {
+	type test struct {
+		input    []string
+		expected []utils.LintProblem
+	}
+
+	tests := []test{
+		{
+			input:    []string{"...#", "#...", "...."},
+			expected: []utils.LintProblem{},
+		},
+		{
+			input: []string{"...#", "#.S.", "..."},
+			expected: []utils.LintProblem{
+				{Line: 2, Message: "unexpected character 'S' at column 3"},
+				{Line: 3, Message: "width is 3, expected 4 like line 1"},
+			},
+		},
+		{
+			input:    []string{},
+			expected: []utils.LintProblem{{Line: 0, Message: "input is empty"}},
+		},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, LintInput(tc.input))
+	}
+}
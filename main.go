@@ -0,0 +1,113 @@
+// Command advent-of-code-2023 runs the days of the calendar:
+//
+//	go run . <day> [subcommand] [-input file] [-example N] [-config file]
+//	go run . all [subcommand] [-example N]
+//
+// Options can come before or after the subcommand of a day. Every day has
+// the lint and config subcommands, some have more, and unknown ones exit
+// with status 2 like unknown days.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	day01 "github.com/angristan/advent-of-code-2023/01"
+	day02 "github.com/angristan/advent-of-code-2023/02"
+	day03 "github.com/angristan/advent-of-code-2023/03"
+	day04 "github.com/angristan/advent-of-code-2023/04"
+	day05 "github.com/angristan/advent-of-code-2023/05"
+	day06 "github.com/angristan/advent-of-code-2023/06"
+	day07 "github.com/angristan/advent-of-code-2023/07"
+	day08 "github.com/angristan/advent-of-code-2023/08"
+	day09 "github.com/angristan/advent-of-code-2023/09"
+	day10 "github.com/angristan/advent-of-code-2023/10"
+	day11 "github.com/angristan/advent-of-code-2023/11"
+	"github.com/angristan/advent-of-code-2023/utils"
+)
+
+type Day struct {
+	Name string
+	Run  func(args []string) error
+}
+
+var days = []Day{
+	{Name: "01", Run: day01.Run},
+	{Name: "02", Run: day02.Run},
+	{Name: "03", Run: day03.Run},
+	{Name: "04", Run: day04.Run},
+	{Name: "05", Run: day05.Run},
+	{Name: "06", Run: day06.Run},
+	{Name: "07", Run: day07.Run},
+	{Name: "08", Run: day08.Run},
+	{Name: "09", Run: day09.Run},
+	{Name: "10", Run: day10.Run},
+	{Name: "11", Run: day11.Run},
+}
+
+// FindDay returns the day named name, "4" being the same as "04".
+func FindDay(name string) (Day, bool) {
+	if number, err := strconv.Atoi(name); err == nil {
+		name = fmt.Sprintf("%02d", number)
+	}
+
+	for _, day := range days {
+		if day.Name == name {
+			return day, true
+		}
+	}
+
+	return Day{}, false
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: %s <day|all> [subcommand] [-input file] [-example N] [-config file]\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "days: %s to %s\n", days[0].Name, days[len(days)-1].Name)
+}
+
+// exitCode reports err, if any, and returns the status to exit with.
+func exitCode(err error) int {
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, utils.ErrLintProblems):
+		// The problems are already reported
+		return 1
+	case errors.Is(err, utils.ErrUnknownSubcommand):
+		log.Print(err)
+		return 2
+	default:
+		log.Print(err)
+		return 1
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	name, args := os.Args[1], os.Args[2:]
+
+	if name == "all" {
+		status := 0
+		for _, day := range days {
+			fmt.Printf("Day %s\n", day.Name)
+			status = max(status, exitCode(day.Run(args)))
+		}
+		os.Exit(status)
+	}
+
+	day, ok := FindDay(name)
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+
+	os.Exit(exitCode(day.Run(args)))
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindDay(t *testing.T) {
+	type test struct {
+		name     string
+		expected string
+		found    bool
+	}
+
+	tests := []test{
+		{name: "04", expected: "04", found: true},
+		{name: "4", expected: "04", found: true},
+		{name: "11", expected: "11", found: true},
+		{name: "12", found: false},
+		{name: "all", found: false},
+	}
+
+	for _, test := range tests {
+		day, found := FindDay(test.name)
+		assert.Equal(t, test.found, found, test.name)
+		assert.Equal(t, test.expected, day.Name, test.name)
+	}
+}
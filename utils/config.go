@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// DayConfig holds the parameters of a day, with its defaults set before
+// LoadConfig overrides them from the configuration file.
+type DayConfig interface {
+	Validate() error
+}
+
+// NoConfig is the configuration of days without parameters, so that their
+// runner defaults still apply and unknown parameters are rejected.
+type NoConfig struct{}
+
+func (NoConfig) Validate() error {
+	return nil
+}
+
+// RunnerConfig holds defaults for the command line options,
+// which still take precedence when given explicitly.
+type RunnerConfig struct {
+	Input   string `json:"input,omitempty"`
+	Example int    `json:"example,omitempty"`
+}
+
+/*
+The configuration file looks like this, every section being optional:
+
+	{
+		"runner": {"example": 1},
+		"days": {
+			"02": {"bag": {"red": 12, "green": 13, "blue": 14}},
+			"08": {"start": "AAA", "end": "ZZZ"}
+		}
+	}
+*/
+
+type configFile struct {
+	Runner RunnerConfig               `json:"runner"`
+	Days   map[string]json.RawMessage `json:"days"`
+}
+
+// LoadConfig reads the file given with -config, applies its runner defaults
+// to the options that weren't set and overrides the parameters of the day.
+// Maps are merged into the defaults key by key, unless their type implements
+// json.Unmarshaler. Without -config, the day parameters are only validated.
+func LoadConfig(options *Options, dayConfig DayConfig) error {
+	day := options.Day
+
+	if options.Config != "" {
+		raw, err := os.ReadFile(options.Config)
+		if err != nil {
+			return err
+		}
+
+		file := configFile{}
+		if err := decodeStrict(raw, &file); err != nil {
+			return fmt.Errorf("%s: %w", options.Config, err)
+		}
+
+		options.applyRunnerDefaults(file.Runner)
+
+		if rawDay, ok := file.Days[day]; ok {
+			if err := decodeStrict(rawDay, dayConfig); err != nil {
+				return fmt.Errorf("%s: day %s: %w", options.Config, day, err)
+			}
+		}
+	}
+
+	if err := dayConfig.Validate(); err != nil {
+		return fmt.Errorf("day %s: %w", day, err)
+	}
+
+	return nil
+}
+
+func decodeStrict(raw []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+
+	return decoder.Decode(v)
+}
+
+func (options *Options) applyRunnerDefaults(runner RunnerConfig) {
+	if !options.explicit["input"] && runner.Input != "" {
+		options.Input = runner.Input
+	}
+	if !options.explicit["example"] && runner.Example != 0 {
+		options.Example = runner.Example
+	}
+}
+
+// PrintConfig writes the effective configuration of a day, in the same
+// format as the configuration file.
+func PrintConfig(out io.Writer, options *Options, dayConfig DayConfig) error {
+	effective := struct {
+		Runner RunnerConfig         `json:"runner"`
+		Days   map[string]DayConfig `json:"days"`
+	}{
+		Runner: RunnerConfig{Input: options.Input, Example: options.Example},
+		Days:   map[string]DayConfig{options.Day: dayConfig},
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(effective)
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testDayConfig struct {
+	Labels map[string]int `json:"labels"`
+	Factor int            `json:"factor"`
+}
+
+func (config testDayConfig) Validate() error {
+	if config.Factor < 1 {
+		return errors.New("factor must be at least 1")
+	}
+
+	return nil
+}
+
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.json")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	options, err := ParseOptions("01", []string{"-config", writeConfig(t, `{
+		"runner": {"example": 2},
+		"days": {
+			"01": {"labels": {"b": 3}},
+			"02": {"factor": 0}
+		}
+	}`)})
+	assert.NoError(t, err)
+
+	config := testDayConfig{Labels: map[string]int{"a": 1, "b": 2}, Factor: 2}
+	assert.NoError(t, LoadConfig(options, &config))
+	assert.Equal(t, testDayConfig{Labels: map[string]int{"a": 1, "b": 3}, Factor: 2}, config)
+	assert.Equal(t, 2, options.Example)
+
+	out := strings.Builder{}
+	assert.NoError(t, PrintConfig(&out, options, config))
+	assert.JSONEq(t, `{"runner": {"example": 2}, "days": {"01": {"labels": {"a": 1, "b": 3}, "factor": 2}}}`, out.String())
+
+	options.Day = "02"
+	config = testDayConfig{Factor: 2}
+	assert.EqualError(t, LoadConfig(options, &config), "day 02: factor must be at least 1")
+
+	options = &Options{Day: "01", Config: writeConfig(t, `{"days": {"01": {"factr": 3}}}`)}
+	config = testDayConfig{Factor: 2}
+	assert.ErrorContains(t, LoadConfig(options, &config), `unknown field "factr"`)
+
+	// Explicit options take precedence over the runner defaults
+	options, err = ParseOptions("01", []string{"-example", "1", "-config", writeConfig(t, `{"runner": {"example": 2}}`)})
+	assert.NoError(t, err)
+	config = testDayConfig{Factor: 2}
+	assert.NoError(t, LoadConfig(options, &config))
+	assert.Equal(t, 1, options.Example)
+}
+
+func TestLoadNoConfig(t *testing.T) {
+	options := &Options{Day: "04", Config: writeConfig(t, `{"runner": {"example": 1}, "days": {"04": {}}}`)}
+	assert.NoError(t, LoadConfig(options, &NoConfig{}))
+	assert.Equal(t, 1, options.Example)
+
+	options = &Options{Day: "04", Config: writeConfig(t, `{"days": {"04": {"start": "AAA"}}}`)}
+	assert.ErrorContains(t, LoadConfig(options, &NoConfig{}), `unknown field "start"`)
+}
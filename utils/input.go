@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// Options are the command line options understood by every day.
+type Options struct {
+	Day     string
+	Input   string
+	Example int
+	Config  string
+
+	args     []string
+	explicit map[string]bool
+}
+
+// ParseOptions parses the command line of day. Options can be given before
+// or after the subcommand, so "lint -input file" reads file like
+// "-input file lint" does. Everything after "--" is kept as arguments.
+func ParseOptions(day string, args []string) (*Options, error) {
+	options := &Options{Day: day, args: []string{}, explicit: map[string]bool{}}
+
+	flags := flag.NewFlagSet(day, flag.ContinueOnError)
+	flags.StringVar(&options.Input, "input", "", "read the puzzle input from this file instead of the embedded one")
+	flags.IntVar(&options.Example, "example", 0, "use the embedded example N instead of the puzzle input")
+	flags.StringVar(&options.Config, "config", "", "read runner defaults and day parameters from this JSON file")
+
+	for len(args) > 0 {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+
+		// The flag package stops at the first argument, or after "--"
+		parsed := args[:len(args)-flags.NArg()]
+		args = flags.Args()
+		if len(parsed) > 0 && parsed[len(parsed)-1] == "--" {
+			options.args = append(options.args, args...)
+			break
+		}
+
+		if len(args) > 0 {
+			options.args = append(options.args, args[0])
+			args = args[1:]
+		}
+	}
+
+	flags.Visit(func(f *flag.Flag) {
+		options.explicit[f.Name] = true
+	})
+
+	return options, nil
+}
+
+// Args returns the arguments left once the options are parsed.
+func (options *Options) Args() []string {
+	return options.args
+}
+
+// Arg returns the i-th argument left once the options are parsed, or an
+// empty string if there is none.
+func (options *Options) Arg(i int) string {
+	if i < 0 || i >= len(options.args) {
+		return ""
+	}
+
+	return options.args[i]
+}
+
+// OpenInput opens the puzzle input selected by the options: the file given
+// with -input, the embedded exampleN.txt with -example N, or the embedded
+// input.txt otherwise. Days embed their files so that the calendar binary
+// works from any working directory.
+func OpenInput(embedded fs.FS, options *Options) (io.ReadCloser, error) {
+	if options.Input != "" {
+		return os.Open(options.Input)
+	}
+
+	name := "input.txt"
+	if options.Example != 0 {
+		name = fmt.Sprintf("example%d.txt", options.Example)
+	}
+
+	return embedded.Open(name)
+}
+
+func ReadInput(embedded fs.FS, options *Options) ([]string, error) {
+	file, err := OpenInput(embedded, options)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ParseLines(file), nil
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadInput(t *testing.T) {
+	embedded := fstest.MapFS{
+		"input.txt":    {Data: []byte("real\ninput\n")},
+		"example1.txt": {Data: []byte("example\n")},
+	}
+
+	externalPath := filepath.Join(t.TempDir(), "external.txt")
+	assert.NoError(t, os.WriteFile(externalPath, []byte("external\n"), 0o644))
+
+	type test struct {
+		options  Options
+		expected []string
+	}
+
+	tests := []test{
+		{options: Options{}, expected: []string{"real", "input"}},
+		{options: Options{Example: 1}, expected: []string{"example"}},
+		{options: Options{Input: externalPath, Example: 1}, expected: []string{"external"}},
+	}
+
+	for _, test := range tests {
+		input, err := ReadInput(embedded, &test.options)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, input)
+	}
+
+	_, err := ReadInput(embedded, &Options{Example: 2})
+	assert.Error(t, err)
+}
+
+func TestParseOptions(t *testing.T) {
+	type test struct {
+		args            []string
+		expectedInput   string
+		expectedExample int
+		expectedArgs    []string
+	}
+
+	tests := []test{
+		{args: []string{}, expectedArgs: []string{}},
+		{args: []string{"-example", "1", "lint"}, expectedExample: 1, expectedArgs: []string{"lint"}},
+		{args: []string{"lint", "-input", "file"}, expectedInput: "file", expectedArgs: []string{"lint"}},
+		{args: []string{"report", "-example=2", "json"}, expectedExample: 2, expectedArgs: []string{"report", "json"}},
+		{args: []string{"filter", "-example", "1", "--", "id", "<", "-3"}, expectedExample: 1, expectedArgs: []string{"filter", "id", "<", "-3"}},
+	}
+
+	for _, test := range tests {
+		options, err := ParseOptions("01", test.args)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expectedInput, options.Input)
+		assert.Equal(t, test.expectedExample, options.Example)
+		assert.Equal(t, test.expectedArgs, options.Args())
+	}
+
+	options, err := ParseOptions("01", []string{"lint"})
+	assert.NoError(t, err)
+	assert.Equal(t, "lint", options.Arg(0))
+	assert.Equal(t, "", options.Arg(1))
+
+	_, err = ParseOptions("01", []string{"lint", "-unknown"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// LintProblem is an issue found in a puzzle input. Line is 1-indexed,
+// 0 means the problem concerns the input as a whole.
+type LintProblem struct {
+	Line    int
+	Message string
+}
+
+func (problem LintProblem) String() string {
+	if problem.Line == 0 {
+		return problem.Message
+	}
+
+	return fmt.Sprintf("line %d: %s", problem.Line, problem.Message)
+}
+
+func Lintf(line int, format string, args ...any) LintProblem {
+	return LintProblem{Line: line, Message: fmt.Sprintf(format, args...)}
+}
+
+// LintGrid checks that the input is a non-empty rectangle made only of
+// the allowed characters.
+func LintGrid(input []string, allowed string) []LintProblem {
+	return LintGridFunc(input, func(char rune) bool {
+		return strings.ContainsRune(allowed, char)
+	})
+}
+
+// LintGridFunc checks that the input is a non-empty rectangle made only of
+// characters accepted by allowed. Widths and columns are counted in runes.
+func LintGridFunc(input []string, allowed func(char rune) bool) []LintProblem {
+	problems := []LintProblem{}
+
+	if len(input) == 0 {
+		return append(problems, Lintf(0, "input is empty"))
+	}
+
+	width := utf8.RuneCountInString(input[0])
+	for y, line := range input {
+		if lineWidth := utf8.RuneCountInString(line); lineWidth != width {
+			problems = append(problems, Lintf(y+1, "width is %d, expected %d like line 1", lineWidth, width))
+		}
+
+		for x, char := range []rune(line) {
+			if !allowed(char) {
+				problems = append(problems, Lintf(y+1, "unexpected character %q at column %d", char, x+1))
+			}
+		}
+	}
+
+	return problems
+}
+
+// ErrLintProblems is returned by the lint subcommand of a day when the input
+// has problems, so that the calendar exits with a non-zero status.
+var ErrLintProblems = errors.New("the input has lint problems")
+
+// ReportLint writes every problem to out and tells whether the input is clean.
+func ReportLint(out io.Writer, problems []LintProblem) bool {
+	for _, problem := range problems {
+		fmt.Fprintln(out, problem)
+	}
+
+	if len(problems) == 0 {
+		fmt.Fprintln(out, "No problems found")
+		return true
+	}
+
+	fmt.Fprintf(out, "%d problem(s) found\n", len(problems))
+	return false
+}
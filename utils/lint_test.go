@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintGrid(t *testing.T) {
+	input := []string{
+		"..#",
+		".x#",
+		"..",
+	}
+
+	want := []LintProblem{
+		{Line: 2, Message: "unexpected character 'x' at column 2"},
+		{Line: 3, Message: "width is 2, expected 3 like line 1"},
+	}
+
+	assert.Equal(t, want, LintGrid(input, ".#"))
+	assert.Equal(t, []LintProblem{{Message: "input is empty"}}, LintGrid([]string{}, ".#"))
+}
+
+func TestLintGridFunc(t *testing.T) {
+	input := []string{
+		"→.#",
+		".é#x",
+	}
+
+	want := []LintProblem{
+		{Line: 2, Message: "width is 4, expected 3 like line 1"},
+		{Line: 2, Message: "unexpected character 'x' at column 4"},
+	}
+
+	allowed := func(char rune) bool { return char != 'x' }
+	assert.Equal(t, want, LintGridFunc(input, allowed))
+}
+
+func TestReportLint(t *testing.T) {
+	out := strings.Builder{}
+	assert.True(t, ReportLint(&out, []LintProblem{}))
+	assert.Equal(t, "No problems found\n", out.String())
+
+	out.Reset()
+	assert.False(t, ReportLint(&out, []LintProblem{Lintf(0, "no map found"), Lintf(4, "bad %s", "line")}))
+	assert.Equal(t, "no map found\nline 4: bad line\n2 problem(s) found\n", out.String())
+}
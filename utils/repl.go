@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type REPLCommand struct {
+	Usage string
+	Run   func(args []string) (string, error)
+}
+
+type REPL struct {
+	Prompt   string
+	Commands map[string]REPLCommand
+}
+
+// Run reads one command per line from in until EOF or "exit",
+// and writes each command's result (or error) to out.
+func (repl REPL) Run(in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+
+	for {
+		fmt.Fprint(out, repl.Prompt)
+
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			return scanner.Err()
+		}
+
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		name, args := fields[0], fields[1:]
+
+		switch name {
+		case "exit", "quit":
+			return nil
+		case "help":
+			fmt.Fprint(out, repl.Help())
+			continue
+		}
+
+		command, ok := repl.Commands[name]
+		if !ok {
+			fmt.Fprintf(out, "unknown command %q, try \"help\"\n", name)
+			continue
+		}
+
+		result, err := command.Run(args)
+		if err != nil {
+			fmt.Fprintf(out, "error: %s\n", err)
+			continue
+		}
+
+		fmt.Fprintln(out, result)
+	}
+}
+
+func (repl REPL) Help() string {
+	names := make([]string, 0, len(repl.Commands))
+	for name := range repl.Commands {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var help strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&help, "  %s\n", repl.Commands[name].Usage)
+	}
+	help.WriteString("  help\n  exit\n")
+
+	return help.String()
+}
+
+// IntArgs converts every REPL argument to an int, so commands can
+// take coordinates or IDs without repeating strconv boilerplate.
+func IntArgs(args []string, want int) ([]int, error) {
+	if len(args) != want {
+		return nil, fmt.Errorf("expected %d arguments, got %d", want, len(args))
+	}
+
+	values := make([]int, 0, len(args))
+	for _, arg := range args {
+		value, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", arg)
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestREPLRun(t *testing.T) {
+	repl := REPL{
+		Prompt: "> ",
+		Commands: map[string]REPLCommand{
+			"echo": {
+				Usage: "echo <words...>",
+				Run: func(args []string) (string, error) {
+					return strings.Join(args, " "), nil
+				},
+			},
+			"fail": {
+				Usage: "fail",
+				Run: func(args []string) (string, error) {
+					return "", errors.New("boom")
+				},
+			},
+		},
+	}
+
+	in := strings.NewReader("echo hello  world\n\nfail\nnope\nexit\necho unreachable\n")
+	out := strings.Builder{}
+
+	assert.NoError(t, repl.Run(in, &out))
+	assert.Equal(t, "> hello world\n> > error: boom\n> unknown command \"nope\", try \"help\"\n> ", out.String())
+}
+
+func TestIntArgs(t *testing.T) {
+	values, err := IntArgs([]string{"3", "-4"}, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, -4}, values)
+
+	_, err = IntArgs([]string{"3"}, 2)
+	assert.Error(t, err)
+
+	_, err = IntArgs([]string{"x"}, 1)
+	assert.Error(t, err)
+}
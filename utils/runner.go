@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+)
+
+var ErrUnknownSubcommand = errors.New("unknown subcommand")
+
+// Subcommand runs on the input of a day instead of solving it.
+type Subcommand struct {
+	Usage string
+	Run   func(input []string, options *Options) error
+}
+
+// DayRunner holds the steps shared by every day: parsing the command line,
+// loading the configuration and reading the input, then solving the puzzle
+// or running the subcommand given as first argument. Every day has the
+// "lint" and "config" subcommands, and "stream" when Stream is set.
+type DayRunner struct {
+	Day    string
+	Inputs fs.FS
+
+	// Config points to the parameters of the day, set to their defaults.
+	// It is loaded before any other step runs.
+	Config DayConfig
+
+	Lint  func(input []string) []LintProblem
+	Solve func(input []string) error
+
+	// Stream solves the puzzle without reading the whole input first,
+	// usually through StreamInput.
+	Stream func(options *Options) error
+
+	Subcommands map[string]Subcommand
+}
+
+// Run runs the day with the command line args, see ParseOptions.
+// Unknown subcommands fail with ErrUnknownSubcommand.
+func (runner DayRunner) Run(args []string) error {
+	options, err := ParseOptions(runner.Day, args)
+	if err != nil {
+		return err
+	}
+
+	if err := LoadConfig(options, runner.Config); err != nil {
+		return err
+	}
+
+	name := options.Arg(0)
+	if name == "stream" && runner.Stream != nil {
+		return runner.Stream(options)
+	}
+
+	subcommand, ok := runner.subcommands()[name]
+	if !ok {
+		return fmt.Errorf("day %s: %w %q, expected none to solve the puzzle or one of: %s",
+			runner.Day, ErrUnknownSubcommand, name, strings.Join(runner.Usages(), ", "))
+	}
+
+	input, err := ReadInput(runner.Inputs, options)
+	if err != nil {
+		return err
+	}
+
+	return subcommand.Run(input, options)
+}
+
+// subcommands returns the subcommands run on the input, the empty name
+// solving the puzzle.
+func (runner DayRunner) subcommands() map[string]Subcommand {
+	subcommands := map[string]Subcommand{
+		"": {
+			Run: func(input []string, options *Options) error {
+				return runner.Solve(input)
+			},
+		},
+		"lint": {
+			Usage: "lint",
+			Run: func(input []string, options *Options) error {
+				if !ReportLint(os.Stdout, runner.Lint(input)) {
+					return ErrLintProblems
+				}
+				return nil
+			},
+		},
+		"config": {
+			Usage: "config",
+			Run: func(input []string, options *Options) error {
+				return PrintConfig(os.Stdout, options, runner.Config)
+			},
+		},
+	}
+
+	for name, subcommand := range runner.Subcommands {
+		subcommands[name] = subcommand
+	}
+
+	return subcommands
+}
+
+// Usages returns the usage of every subcommand of the day, sorted.
+func (runner DayRunner) Usages() []string {
+	usages := []string{}
+	for name, subcommand := range runner.subcommands() {
+		if name != "" {
+			usages = append(usages, subcommand.Usage)
+		}
+	}
+	if runner.Stream != nil {
+		usages = append(usages, "stream")
+	}
+	slices.Sort(usages)
+
+	return usages
+}
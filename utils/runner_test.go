@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testConfig is a day parameter, to check it is loaded before running
+type testConfig struct {
+	Greeting string `json:"greeting"`
+}
+
+func (config testConfig) Validate() error {
+	if config.Greeting == "" {
+		return errors.New("greeting is empty")
+	}
+
+	return nil
+}
+
+// newTestRunner returns a day runner writing what it runs to got
+func newTestRunner(got *string) DayRunner {
+	config := testConfig{Greeting: "hello"}
+
+	return DayRunner{
+		Day: "00",
+		Inputs: fstest.MapFS{
+			"input.txt":    {Data: []byte("puzzle")},
+			"example1.txt": {Data: []byte("example")},
+		},
+		Config: &config,
+		Lint:   func(input []string) []LintProblem { return nil },
+		Solve: func(input []string) error {
+			*got = "solve " + config.Greeting + " " + strings.Join(input, " ")
+			return nil
+		},
+		Stream: func(options *Options) error {
+			*got = "stream " + config.Greeting
+			return nil
+		},
+		Subcommands: map[string]Subcommand{
+			"echo": {
+				Usage: "echo <words...>",
+				Run: func(input []string, options *Options) error {
+					*got = "echo " + strings.Join(append(input, options.Args()[1:]...), " ")
+					return nil
+				},
+			},
+		},
+	}
+}
+
+func TestDayRunner(t *testing.T) {
+	type test struct {
+		args     []string
+		expected string
+	}
+
+	tests := []test{
+		{args: []string{}, expected: "solve hello puzzle"},
+		{args: []string{"-example", "1"}, expected: "solve hello example"},
+		{args: []string{"echo", "a", "-example", "1", "b"}, expected: "echo example a b"},
+		{args: []string{"stream"}, expected: "stream hello"},
+	}
+
+	for _, test := range tests {
+		got := ""
+		err := newTestRunner(&got).Run(test.args)
+
+		assert.NoError(t, err, test.args)
+		assert.Equal(t, test.expected, got, test.args)
+	}
+}
+
+func TestDayRunnerUnknownSubcommand(t *testing.T) {
+	got := ""
+	runner := newTestRunner(&got)
+
+	err := runner.Run([]string{"lnt"})
+	assert.ErrorIs(t, err, ErrUnknownSubcommand)
+	assert.EqualError(t, err, `day 00: unknown subcommand "lnt", expected none to solve the puzzle or one of: config, echo <words...>, lint, stream`)
+	assert.Empty(t, got)
+
+	// Without a stream solver, stream is unknown too
+	runner.Stream = nil
+	assert.ErrorIs(t, runner.Run([]string{"stream"}), ErrUnknownSubcommand)
+	assert.Equal(t, []string{"config", "echo <words...>", "lint"}, runner.Usages())
+	assert.Empty(t, got)
+}
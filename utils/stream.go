@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"bufio"
+	"io/fs"
+	"runtime"
+)
+
+// LineIterator yields the input one line at a time, so that streaming
+// solvers don't need the whole file in memory. *bufio.Scanner implements it.
+type LineIterator interface {
+	Scan() bool
+	Text() string
+	Err() error
+}
+
+// StreamInput opens the input selected by the options, like ReadInput,
+// and hands its lines to solve one at a time.
+func StreamInput(embedded fs.FS, options *Options, solve func(lines LineIterator) error) error {
+	file, err := OpenInput(embedded, options)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return solve(bufio.NewScanner(file))
+}
+
+// GeneratedLines is a LineIterator building Count lines on demand with Line,
+// used to stress streaming solvers with inputs that would not fit in memory.
+// AtEnd, if set, is called once all the lines have been consumed.
+type GeneratedLines struct {
+	Count int
+	Line  func(i int) string
+	AtEnd func()
+
+	index int
+	text  string
+}
+
+func (lines *GeneratedLines) Scan() bool {
+	if lines.index >= lines.Count {
+		if lines.AtEnd != nil {
+			lines.AtEnd()
+			lines.AtEnd = nil
+		}
+		return false
+	}
+
+	lines.text = lines.Line(lines.index)
+	lines.index++
+
+	return true
+}
+
+func (lines *GeneratedLines) Text() string {
+	return lines.text
+}
+
+func (lines *GeneratedLines) Err() error {
+	return nil
+}
+
+// LiveHeap returns the number of heap bytes still reachable after a garbage
+// collection, to assert on the memory a solver holds on to.
+func LiveHeap() uint64 {
+	runtime.GC()
+
+	stats := runtime.MemStats{}
+	runtime.ReadMemStats(&stats)
+
+	return stats.HeapAlloc
+}
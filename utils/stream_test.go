@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"errors"
+	"runtime"
+	"strconv"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// collectLines drains lines
+func collectLines(lines LineIterator) ([]string, error) {
+	collected := []string{}
+	for lines.Scan() {
+		collected = append(collected, lines.Text())
+	}
+
+	return collected, lines.Err()
+}
+
+func TestStreamInput(t *testing.T) {
+	embedded := fstest.MapFS{
+		"input.txt":    {Data: []byte("first\n\nthird")},
+		"example1.txt": {Data: []byte("example\n")},
+	}
+
+	type test struct {
+		options  Options
+		expected []string
+	}
+
+	tests := []test{
+		{options: Options{}, expected: []string{"first", "", "third"}},
+		{options: Options{Example: 1}, expected: []string{"example"}},
+	}
+
+	for _, test := range tests {
+		err := StreamInput(embedded, &test.options, func(lines LineIterator) error {
+			collected, err := collectLines(lines)
+			assert.Equal(t, test.expected, collected)
+
+			return err
+		})
+		assert.NoError(t, err)
+	}
+
+	err := StreamInput(embedded, &Options{}, func(lines LineIterator) error {
+		return errors.New("boom")
+	})
+	assert.EqualError(t, err, "boom")
+
+	err = StreamInput(embedded, &Options{Example: 2}, func(lines LineIterator) error {
+		t.Error("solve called without input")
+		return nil
+	})
+	assert.Error(t, err)
+}
+
+func TestGeneratedLines(t *testing.T) {
+	type test struct {
+		count    int
+		expected []string
+	}
+
+	tests := []test{
+		{count: 0, expected: []string{}},
+		{count: 1, expected: []string{"0"}},
+		{count: 3, expected: []string{"0", "1", "2"}},
+	}
+
+	for _, test := range tests {
+		atEndCalls := 0
+		lines := &GeneratedLines{
+			Count: test.count,
+			Line:  strconv.Itoa,
+			AtEnd: func() { atEndCalls++ },
+		}
+
+		collected, err := collectLines(lines)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, collected)
+
+		// Scanning past the end neither yields lines nor calls AtEnd again
+		assert.False(t, lines.Scan())
+		assert.Equal(t, 1, atEndCalls)
+	}
+}
+
+func TestLiveHeap(t *testing.T) {
+	before := LiveHeap()
+
+	// Other garbage can be collected meanwhile, hence the 1 MiB of slack
+	held := make([]byte, 64<<20)
+	assert.Greater(t, int64(LiveHeap())-int64(before), int64(len(held)-1<<20))
+	runtime.KeepAlive(held)
+
+	assert.Less(t, int64(LiveHeap())-int64(before), int64(1<<20))
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"bufio"
+	"io"
 	"os"
 )
 
@@ -16,8 +17,12 @@ func ParseInput(filename string) []string {
 	}
 	defer file.Close()
 
+	return ParseLines(file)
+}
+
+func ParseLines(reader io.Reader) []string {
 	var input []string
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(reader)
 	for scanner.Scan() {
 		input = append(input, scanner.Text())
 	}